@@ -0,0 +1,129 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"testing"
+
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestScan_arrays(t *testing.T) {
+	one, two := int32(1), int32(2)
+
+	type args struct {
+		names []string
+		rows  [][]interface{}
+		opts  []Option
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantResults []*support.Grid
+		wantErr     bool
+	}{
+		{
+			"multi-dimensional",
+			args{
+				[]string{"id", "rows", "planes"},
+				[][]interface{}{
+					{int32(1), [][]int32{{1, 2}, {3, 4}}, [][][]int32{{{5}}, {{6}}}},
+					{int32(2), nil, nil},
+				},
+				nil,
+			},
+			[]*support.Grid{
+				{
+					Id: 1,
+					Rows: []*support.Row{
+						{Cells: []int32{1, 2}},
+						{Cells: []int32{3, 4}},
+					},
+					Planes: []*support.Plane{
+						{Rows: []*support.Row{{Cells: []int32{5}}}},
+						{Rows: []*support.Row{{Cells: []int32{6}}}},
+					},
+				},
+				{Id: 2},
+			},
+			false,
+		},
+		{
+			"NULL element",
+			args{
+				[]string{"tags"},
+				[][]interface{}{
+					{[]*string{nil}},
+				},
+				nil,
+			},
+			nil,
+			true,
+		},
+		{
+			"skip NULL elements",
+			args{
+				[]string{"rows"},
+				[][]interface{}{
+					{[][]*int32{{&one, nil}, {nil, &two}}},
+				},
+				[]Option{WithSkipNullElements()},
+			},
+			[]*support.Grid{
+				{Rows: []*support.Row{{Cells: []int32{1}}, {Cells: []int32{2}}}},
+			},
+			false,
+		},
+		{
+			"zero NULL elements",
+			args{
+				[]string{"rows"},
+				[][]interface{}{
+					{[][]*int32{{&one, nil}, {nil, &two}}},
+				},
+				[]Option{WithZeroNullElements()},
+			},
+			[]*support.Grid{
+				{Rows: []*support.Row{{Cells: []int32{1, 0}}, {Cells: []int32{0, 2}}}},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResults, err := Scan[*support.Grid](newTestRows(tt.args.names, tt.args.rows), tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if len(gotResults) != len(tt.wantResults) {
+				t.Errorf("Scan() =\n%s\nwant\n%s", gotResults, tt.wantResults)
+			}
+
+			for i, want := range tt.wantResults {
+				if !proto.Equal(gotResults[i], want) {
+					t.Errorf("Scan() =\n%s\nwant\n%s", gotResults[i], want)
+				}
+			}
+		})
+	}
+}
@@ -23,11 +23,10 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx"
 	"github.com/muhlemmer/pbpgx/internal/value"
 	"golang.org/x/exp/constraints"
 	"google.golang.org/protobuf/proto"
-	pr "google.golang.org/protobuf/reflect/protoreflect"
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func contains[T constraints.Ordered](v T, list []T) bool {
@@ -40,13 +39,20 @@ func contains[T constraints.Ordered](v T, list []T) bool {
 	return false
 }
 
-// ColNames expresses proto.Message field names for use as column specifiers.
+// ColNames expresses column names, which map to proto.Message fields.
+// A column name is the field name, unless set otherwise with the (pbpgx.column) field option.
 type ColNames []string
 
-// ParseFields returns a slice of field names from the passed proto message.
-// Optionaly, empty (zero-value) fields can be skipped.
-// Note that names are case sensitive, as defined in the proto file, not the Go struct field names.
+// ParseFields returns a slice of column names from the passed proto message.
+// Optionaly, empty (zero-value) fields can be skipped and fields can be ignored by name.
+// Note that ignored names are proto field names, not column names,
+// and are case sensitive, as defined in the proto file, not the Go struct field names.
+// See Table.ParseFields for using a pbpgx.NamingStrategy.
 func ParseFields(msg proto.Message, skipEmpty bool, ignore ...string) (cols ColNames) {
+	return parseFields(nil, msg, skipEmpty, ignore)
+}
+
+func parseFields(vo *value.Options, msg proto.Message, skipEmpty bool, ignore []string) (cols ColNames) {
 	rm := msg.ProtoReflect()
 	fields := rm.Descriptor().Fields()
 
@@ -54,18 +60,21 @@ func ParseFields(msg proto.Message, skipEmpty bool, ignore ...string) (cols ColN
 
 	for i := 0; i < fields.Len(); i++ {
 		fd := fields.Get(i)
-		name := string(fd.Name())
 
-		if skipEmpty && !rm.Has(fd) || contains(name, ignore) {
+		if skipEmpty && !rm.Has(fd) || contains(string(fd.Name()), ignore) {
 			continue
 		}
 
-		cols = append(cols, name)
+		cols = append(cols, vo.Column(fd))
 	}
 
 	return cols
 }
 
+func valueOptions(opts []pbpgx.Option) *value.Options {
+	return value.NewOptions(opts)
+}
+
 type OnEmpty int
 
 const (
@@ -85,44 +94,52 @@ func (o OnEmpty) pgStatus() pgtype.Status {
 // This affects the write behaviour of emtpy fields during Create (INSERT) and Update (UPDATE) calls.
 type Columns map[string]OnEmpty
 
-// ParseArgs parses the values from the fields named by fieldNames.
+// ParseArgs parses the values from the fields which map to colNames.
 // The returned args contains pgtype values for efficient encoding.
 // Empty fields will be set as `Null` by default, unless when set to `Zero`
 // in Columns. Columns may be nil.
-func (columns Columns) ParseArgs(msg proto.Message, colNames ColNames) (args []interface{}, err error) {
+// Optional fields, oneof members and fields of wrapper types, such as google.protobuf.Int64Value,
+// are always set as `Null` when unset, and written with their value when set,
+// even if it is the zero value.
+// A column named after a oneof is a discriminator column,
+// which is set to the field name of the set member, or `Null` if none is set.
+// Options may be passed to set the Encoding of columns and the naming of columns.
+func (columns Columns) ParseArgs(msg proto.Message, colNames ColNames, opts ...pbpgx.Option) (args []interface{}, err error) {
+	return columns.parseArgs(msg, colNames, valueOptions(opts))
+}
+
+func (columns Columns) parseArgs(msg proto.Message, colNames ColNames, vo *value.Options) (args []interface{}, err error) {
 	rm := msg.ProtoReflect()
 	fields := rm.Descriptor().Fields()
 
 	args = make([]interface{}, 0, len(colNames)+5)
 
 	for _, name := range colNames {
-		fd := fields.ByName(pr.Name(name))
+		fd := vo.Field(fields, name)
 		if fd == nil {
-			return nil, fmt.Errorf("ParseArgs: field %q not in msg %T", name, msg)
+			od := vo.Oneof(rm.Descriptor().Oneofs(), name)
+			if od == nil {
+				return nil, fmt.Errorf("ParseArgs: no field for column %q in msg %T", name, msg)
+			}
+
+			arg := vo.NewOneof(od, pgtype.Null)
+			if err = arg.SetFrom(rm); err != nil {
+				return nil, fmt.Errorf("ParseArgs: oneof %q: %w", name, err)
+			}
+
+			args = append(args, arg)
+			continue
 		}
 
-		arg, err := value.New(fd, columns[name].pgStatus())
+		arg, err := vo.New(fd, columns[name].pgStatus(), name, 0)
 		if err != nil {
 			return nil, fmt.Errorf("ParseArgs: %w", err)
 		}
 
 		if rm.Has(fd) {
-			if fd.Kind() == pr.MessageKind {
-				switch x := rm.Get(fd).Message().Interface().(type) {
-
-				case *timestamppb.Timestamp:
-					arg.Set(x.AsTime())
-
-				default:
-					// Ussualy a similar error is returned from NewValue.
-					// Just checking here to be sure we didn't miss anything.
-					return nil, fmt.Errorf("ParseArgs: unsupported message type %q", fd.Message().FullName())
-				}
-
-			} else {
-				arg.Set(rm.Get(fd).Interface())
+			if err = arg.SetFrom(rm); err != nil {
+				return nil, fmt.Errorf("ParseArgs: field %q: %w", name, err)
 			}
-
 		}
 
 		args = append(args, arg)
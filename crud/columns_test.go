@@ -20,15 +20,31 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package crud
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/muhlemmer/pbpgx"
 	"github.com/muhlemmer/pbpgx/internal/support"
+	"github.com/muhlemmer/pbpgx/internal/testlib"
 	"github.com/muhlemmer/pbpgx/internal/value"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestParseFields(t *testing.T) {
@@ -61,6 +77,26 @@ func TestParseFields(t *testing.T) {
 			nil,
 			ColNames{"id", "title"},
 		},
+		{
+			"column option",
+			&support.Legacy{
+				Id:    78,
+				Title: "foo bar",
+			},
+			false,
+			[]string{"data"},
+			ColNames{"legacy_id", "legacy_title"},
+		},
+		{
+			"ignore field name with column option",
+			&support.Legacy{
+				Id:    78,
+				Title: "foo bar",
+			},
+			false,
+			[]string{"title", "legacy_id"},
+			ColNames{"legacy_id", "data"},
+		},
 		{
 			"ignore id, title",
 			&support.Simple{
@@ -80,7 +116,11 @@ func TestParseFields(t *testing.T) {
 			ColNames{
 				"bl", "i32", "i64", "f", "d", "s", "bt", "u32", "u64", "ts",
 				"r_bl", "r_i32", "r_i64", "r_f", "r_d", "r_s", "r_u32", "r_bt", "r_u64", "r_ts",
-				"ob", "oi",
+				"ob", "oi", "en", "r_en", "mp", "ts_mp", "s_mp",
+				"w_bl", "w_i32", "w_i64", "w_f", "w_d", "w_s", "w_bt", "w_u32", "w_u64",
+				"du", "r_du", "st", "val", "lv", "r_st",
+				"dt", "tod", "ll", "dec", "mon", "r_dt", "r_tod", "r_ll", "r_dec", "r_mon",
+				"o_i32", "o_s", "o_en", "o_ts",
 			},
 		},
 		{
@@ -104,10 +144,26 @@ func TestParseFields(t *testing.T) {
 	}
 }
 
+// jsonbEqual compares the JSON documents in a and b semantically,
+// as protojson output is not stable.
+func jsonbEqual(a, b *pgtype.JSONB) bool {
+	if a.Status != pgtype.Present || b.Status != pgtype.Present {
+		return reflect.DeepEqual(a, b)
+	}
+
+	var x, y interface{}
+	if json.Unmarshal(a.Bytes, &x) != nil || json.Unmarshal(b.Bytes, &y) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(x, y)
+}
+
 func TestColumns_ParseArgs(t *testing.T) {
 	type args struct {
 		msg  proto.Message
 		cols ColNames
+		opts []pbpgx.Option
 	}
 	tests := []struct {
 		name     string
@@ -221,6 +277,360 @@ func TestColumns_ParseArgs(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"repeated",
+			nil,
+			args{
+				msg: &support.Supported{
+					RI32: []int32{1, 2},
+					RS:   []string{"foo", "bar"},
+				},
+				cols: []string{"r_i32", "r_s"},
+			},
+			[]interface{}{
+				&pgtype.Int4Array{
+					Elements: []pgtype.Int4{
+						{Int: 1, Status: pgtype.Present},
+						{Int: 2, Status: pgtype.Present},
+					},
+					Dimensions: []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}},
+					Status:     pgtype.Present,
+				},
+				&pgtype.TextArray{
+					Elements: []pgtype.Text{
+						{String: "foo", Status: pgtype.Present},
+						{String: "bar", Status: pgtype.Present},
+					},
+					Dimensions: []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}},
+					Status:     pgtype.Present,
+				},
+			},
+			false,
+		},
+		{
+			"enums",
+			nil,
+			args{
+				msg: &support.Supported{
+					En:  support.SimpleColumns_title,
+					REn: []support.SimpleColumns{support.SimpleColumns_data},
+				},
+				cols: []string{"en", "r_en"},
+			},
+			[]interface{}{
+				&pgtype.Int4{Int: 1, Status: pgtype.Present},
+				&pgtype.Int4Array{
+					Elements:   []pgtype.Int4{{Int: 2, Status: pgtype.Present}},
+					Dimensions: []pgtype.ArrayDimension{{Length: 1, LowerBound: 1}},
+					Status:     pgtype.Present,
+				},
+			},
+			false,
+		},
+		{
+			"enum names",
+			nil,
+			args{
+				msg: &support.Supported{
+					En:  support.SimpleColumns_title,
+					REn: []support.SimpleColumns{support.SimpleColumns_data},
+				},
+				cols: []string{"en", "r_en"},
+				opts: []pbpgx.Option{
+					pbpgx.WithEncoding("en", pbpgx.EnumName),
+					pbpgx.WithEncoding("r_en", pbpgx.EnumName),
+				},
+			},
+			[]interface{}{
+				&pgtype.Text{String: "title", Status: pgtype.Present},
+				&pgtype.TextArray{
+					Elements:   []pgtype.Text{{String: "data", Status: pgtype.Present}},
+					Dimensions: []pgtype.ArrayDimension{{Length: 1, LowerBound: 1}},
+					Status:     pgtype.Present,
+				},
+			},
+			false,
+		},
+		{
+			"maps",
+			Columns{"mp": Zero},
+			args{
+				msg: &support.Supported{
+					SMp: map[string]string{"foo": "bar"},
+				},
+				cols: []string{"mp", "ts_mp", "s_mp"},
+				opts: []pbpgx.Option{
+					pbpgx.WithEncoding("s_mp", pbpgx.Hstore),
+				},
+			},
+			[]interface{}{
+				&pgtype.JSONB{Bytes: []byte("{}"), Status: pgtype.Present},
+				&pgtype.JSONB{Status: pgtype.Null},
+				&pgtype.Hstore{
+					Map:    map[string]pgtype.Text{"foo": {String: "bar", Status: pgtype.Present}},
+					Status: pgtype.Present,
+				},
+			},
+			false,
+		},
+		{
+			"wrappers",
+			Columns{"w_i64": Zero, "w_s": Zero},
+			args{
+				msg: &support.Supported{
+					WI32: wrapperspb.Int32(0),
+				},
+				cols: []string{"w_i32", "w_i64", "w_s"},
+			},
+			[]interface{}{
+				&pgtype.Int4{Int: 0, Status: pgtype.Present},
+				&pgtype.Int8{Status: pgtype.Null},
+				&pgtype.Text{Status: pgtype.Null},
+			},
+			false,
+		},
+		{
+			"duration",
+			Columns{"du": Zero},
+			args{
+				msg: &support.Supported{
+					Du: durationpb.New(time.Minute),
+				},
+				cols: []string{"du"},
+			},
+			[]interface{}{
+				&pgtype.Interval{Microseconds: 60000000, Status: pgtype.Present},
+			},
+			false,
+		},
+		{
+			"struct",
+			Columns{"lv": Zero},
+			args{
+				msg: &support.Supported{
+					Val: structpb.NewBoolValue(true),
+				},
+				cols: []string{"st", "val", "lv"},
+			},
+			[]interface{}{
+				&pgtype.JSONB{Status: pgtype.Null},
+				&pgtype.JSONB{Bytes: []byte("true"), Status: pgtype.Present},
+				&pgtype.JSONB{Bytes: []byte("[]"), Status: pgtype.Present},
+			},
+			false,
+		},
+		{
+			"optional",
+			Columns{"o_i32": Zero, "o_s": Zero, "o_en": Zero},
+			args{
+				msg: &support.Supported{
+					OI32: proto.Int32(0),
+				},
+				cols: []string{"o_i32", "o_s", "o_en"},
+			},
+			[]interface{}{
+				&pgtype.Int4{Int: 0, Status: pgtype.Present},
+				&pgtype.Text{Status: pgtype.Null},
+				&pgtype.Int4{Status: pgtype.Null},
+			},
+			false,
+		},
+		{
+			"oneof",
+			Columns{"ob": Zero, "oi": Zero, "o": Zero},
+			args{
+				msg:  &support.Supported{O: &support.Supported_Oi{Oi: 0}},
+				cols: []string{"ob", "oi", "o"},
+			},
+			[]interface{}{
+				&pgtype.Bool{Status: pgtype.Null},
+				&pgtype.Int4{Int: 0, Status: pgtype.Present},
+				&pgtype.Text{String: "oi", Status: pgtype.Present},
+			},
+			false,
+		},
+		{
+			"oneof unset",
+			nil,
+			args{
+				msg:  &support.Supported{},
+				cols: []string{"o", "ob"},
+			},
+			[]interface{}{
+				&pgtype.Text{Status: pgtype.Null},
+				&pgtype.Bool{Status: pgtype.Null},
+			},
+			false,
+		},
+		{
+			"column option",
+			Columns{"legacy_id": Zero},
+			args{
+				msg:  &support.Legacy{Title: "foo"},
+				cols: []string{"legacy_id", "legacy_title", "data"},
+			},
+			[]interface{}{
+				&pgtype.Int4{Status: pgtype.Present},
+				&pgtype.Text{String: "foo", Status: pgtype.Present},
+				&pgtype.Text{Status: pgtype.Null},
+			},
+			false,
+		},
+		{
+			"column option, field name",
+			nil,
+			args{
+				msg:  &support.Legacy{Title: "foo"},
+				cols: []string{"title"},
+			},
+			nil,
+			true,
+		},
+		{
+			"unsigned wide",
+			nil,
+			args{
+				msg: &support.Supported{
+					U32: math.MaxUint32,
+					U64: math.MaxUint64,
+				},
+				cols: []string{"u32", "u64"},
+				opts: []pbpgx.Option{
+					pbpgx.WithUnsignedEncoding(pbpgx.UnsignedWide),
+				},
+			},
+			[]interface{}{
+				&pgtype.Int8{Int: math.MaxUint32, Status: pgtype.Present},
+				&pgtype.Numeric{Int: new(big.Int).SetUint64(math.MaxUint64), Status: pgtype.Present},
+			},
+			false,
+		},
+		{
+			"JSON message",
+			nil,
+			args{
+				msg: &support.Unsupported{
+					Sup: &support.Supported{I32: 2},
+				},
+				cols: []string{"sup"},
+				opts: []pbpgx.Option{
+					pbpgx.WithMessageEncoding(pbpgx.JSON),
+					pbpgx.WithProtoJSON(protojson.MarshalOptions{UseProtoNames: true}, protojson.UnmarshalOptions{}),
+				},
+			},
+			[]interface{}{
+				&pgtype.JSONB{Bytes: []byte(`{"i32":2}`), Status: pgtype.Present},
+			},
+			false,
+		},
+		{
+			"Binary message",
+			nil,
+			args{
+				msg: &support.Unsupported{
+					Sup: &support.Supported{I32: 1},
+				},
+				cols: []string{"sup"},
+				opts: []pbpgx.Option{pbpgx.WithEncoding("sup", pbpgx.Binary)},
+			},
+			[]interface{}{
+				&pgtype.Bytea{Bytes: []byte{0x10, 0x01}, Status: pgtype.Present},
+			},
+			false,
+		},
+		{
+			"multi-dimensional array",
+			nil,
+			args{
+				msg: &support.Grid{
+					Rows: []*support.Row{
+						{Cells: []int32{1, 2}},
+						{Cells: []int32{3, 4}},
+					},
+				},
+				cols: []string{"rows", "planes"},
+			},
+			[]interface{}{
+				&pgtype.Int4Array{
+					Elements: []pgtype.Int4{
+						{Int: 1, Status: pgtype.Present},
+						{Int: 2, Status: pgtype.Present},
+						{Int: 3, Status: pgtype.Present},
+						{Int: 4, Status: pgtype.Present},
+					},
+					Dimensions: []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}, {Length: 2, LowerBound: 1}},
+					Status:     pgtype.Present,
+				},
+				&pgtype.Int4Array{Status: pgtype.Null},
+			},
+			false,
+		},
+		{
+			"ragged array error",
+			nil,
+			args{
+				msg: &support.Grid{
+					Rows: []*support.Row{
+						{Cells: []int32{1, 2}},
+						{Cells: []int32{3}},
+					},
+				},
+				cols: []string{"rows"},
+			},
+			nil,
+			true,
+		},
+		{
+			"range",
+			nil,
+			args{
+				msg: &support.Booking{
+					Seats: &support.IntRange{Lower: proto.Int64(1)},
+				},
+				cols: []string{"seats", "days"},
+			},
+			[]interface{}{
+				&pgtype.Int8range{
+					Lower:     pgtype.Int8{Int: 1, Status: pgtype.Present},
+					LowerType: pgtype.Inclusive,
+					UpperType: pgtype.Unbounded,
+					Status:    pgtype.Present,
+				},
+				&pgtype.Daterange{Status: pgtype.Null},
+			},
+			false,
+		},
+		{
+			"uuid string and bytes",
+			nil,
+			args{
+				msg: &support.Supported{
+					S:  "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+					Bt: []byte{0xa0, 0xee, 0xbc, 0x99, 0x9c, 0x0b, 0x4e, 0xf8, 0xbb, 0x6d, 0x6b, 0xb9, 0xbd, 0x38, 0x0a, 0x11},
+				},
+				cols: []string{"s", "bt"},
+				opts: []pbpgx.Option{
+					pbpgx.WithEncoding("s", pbpgx.UUID),
+					pbpgx.WithEncoding("bt", pbpgx.UUID),
+				},
+			},
+			[]interface{}{
+				&pgtype.UUID{Bytes: [16]byte{0xa0, 0xee, 0xbc, 0x99, 0x9c, 0x0b, 0x4e, 0xf8, 0xbb, 0x6d, 0x6b, 0xb9, 0xbd, 0x38, 0x0a, 0x11}, Status: pgtype.Present},
+				&pgtype.UUID{Bytes: [16]byte{0xa0, 0xee, 0xbc, 0x99, 0x9c, 0x0b, 0x4e, 0xf8, 0xbb, 0x6d, 0x6b, 0xb9, 0xbd, 0x38, 0x0a, 0x11}, Status: pgtype.Present},
+			},
+			false,
+		},
+		{
+			"unknown enum number error",
+			nil,
+			args{
+				msg:  &support.Supported{En: 99},
+				cols: []string{"en"},
+				opts: []pbpgx.Option{pbpgx.WithEncoding("en", pbpgx.EnumName)},
+			},
+			nil,
+			true,
+		},
 		{
 			"unsupported error",
 			nil,
@@ -247,7 +657,7 @@ func TestColumns_ParseArgs(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			gotArgs, err := tt.columns.ParseArgs(tt.args.msg, tt.args.cols)
+			gotArgs, err := tt.columns.ParseArgs(tt.args.msg, tt.args.cols, tt.args.opts...)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("query.parseArgs() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -265,6 +675,9 @@ func TestColumns_ParseArgs(t *testing.T) {
 				case *pgtype.Timestamptz:
 					ok = x.Time.Equal(tt.wantArgs[i].(*pgtype.Timestamptz).Time)
 
+				case *pgtype.JSONB:
+					ok = jsonbEqual(x, tt.wantArgs[i].(*pgtype.JSONB))
+
 				default:
 					ok = reflect.DeepEqual(x, tt.wantArgs[i])
 				}
@@ -277,3 +690,205 @@ func TestColumns_ParseArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestColumns_ParseArgs_dynamic(t *testing.T) {
+	msg := &support.Supported{
+		I32: 1,
+		Ts:  timestamppb.New(time.Unix(12, 0)),
+		RTs: []*timestamppb.Timestamp{{Seconds: 1}, {Seconds: 2}},
+		Du:  durationpb.New(time.Second),
+		Dt:  &date.Date{Year: 2022, Month: 2, Day: 28},
+		WS:  wrapperspb.String("foo"),
+	}
+	cols := ColNames{"i32", "ts", "r_ts", "du", "dt", "w_s"}
+
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dyn := dynamicpb.NewMessage(msg.ProtoReflect().Descriptor())
+	if err = proto.Unmarshal(data, dyn); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := Columns{}.ParseArgs(msg, cols)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Columns{}.ParseArgs(dyn, cols)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range got {
+		g, w := got[i].(value.Value).PGValue(), want[i].(value.Value).PGValue()
+		if !reflect.DeepEqual(g, w) {
+			t.Errorf("Columns.ParseArgs() %s = %#v, want %#v", cols[i], g, w)
+		}
+	}
+}
+
+func scanOne[M proto.Message](rows pgx.Rows, opts ...pbpgx.Option) (proto.Message, error) {
+	return pbpgx.ScanOne[M](rows, opts...)
+}
+
+// TestColumns_ParseArgs_database writes the args of a message to a table
+// and scans the returned row, for column types which need a database to verify the encoding.
+func TestColumns_ParseArgs_database(t *testing.T) {
+	tests := []struct {
+		name  string
+		table string
+		msg   proto.Message
+		cols  ColNames
+		opts  []pbpgx.Option
+		scan  func(pgx.Rows, ...pbpgx.Option) (proto.Message, error)
+	}{
+		{
+			"enum",
+			"supported",
+			&support.Supported{
+				En:  support.SimpleColumns_title,
+				REn: []support.SimpleColumns{support.SimpleColumns_id, support.SimpleColumns_created},
+			},
+			ColNames{"en", "r_en"},
+			[]pbpgx.Option{pbpgx.WithEncoding("en", pbpgx.EnumName), pbpgx.WithEncoding("r_en", pbpgx.EnumName)},
+			scanOne[*support.Supported],
+		},
+		{
+			"hstore",
+			"supported",
+			&support.Supported{SMp: map[string]string{"foo": "bar", "hello": "world"}},
+			ColNames{"s_mp"},
+			[]pbpgx.Option{pbpgx.WithEncoding("s_mp", pbpgx.Hstore)},
+			scanOne[*support.Supported],
+		},
+		{
+			"interval",
+			"supported",
+			&support.Supported{
+				Du:  &durationpb.Duration{Seconds: 3723, Nanos: 4000},
+				RDu: []*durationpb.Duration{{Seconds: 1}, {Seconds: -90061}},
+			},
+			ColNames{"du", "r_du"},
+			nil,
+			scanOne[*support.Supported],
+		},
+		{
+			"money",
+			"supported",
+			&support.Supported{
+				Mon:  &money.Money{CurrencyCode: "EUR", Units: -1, Nanos: -750000000},
+				RMon: []*money.Money{{CurrencyCode: "USD", Nanos: 1}, {CurrencyCode: "JPY", Units: 100}},
+			},
+			ColNames{"mon", "r_mon"},
+			nil,
+			scanOne[*support.Supported],
+		},
+		{
+			"uuid, inet, macaddr",
+			"supported",
+			&support.Supported{
+				S:  "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+				Bt: []byte{0xa0, 0xee, 0xbc, 0x99, 0x9c, 0x0b, 0x4e, 0xf8, 0xbb, 0x6d, 0x6b, 0xb9, 0xbd, 0x38, 0x0a, 0x11},
+				RS: []string{"08:00:2b:01:02:03", "08:00:2b:01:02:04"},
+				OS: proto.String("192.168.0.1"),
+			},
+			ColNames{"s", "bt", "r_s", "o_s"},
+			[]pbpgx.Option{
+				pbpgx.WithEncoding("s", pbpgx.UUID),
+				pbpgx.WithEncoding("bt", pbpgx.UUID),
+				pbpgx.WithEncoding("r_s", pbpgx.Macaddr),
+				pbpgx.WithEncoding("o_s", pbpgx.Inet),
+			},
+			scanOne[*support.Supported],
+		},
+		{
+			"bytea message",
+			"parent_blobs",
+			&support.Parent{
+				Id:    1,
+				Title: "foo",
+				Children: []*support.Child{
+					{Id: 2, Name: "bar"},
+					{Id: 3, Name: "baz", Born: &timestamppb.Timestamp{Seconds: 12}},
+				},
+				Favorite: &support.Child{Id: 3, Name: "baz"},
+			},
+			ColNames{"id", "title", "children", "favorite"},
+			[]pbpgx.Option{pbpgx.WithMessageEncoding(pbpgx.Binary)},
+			scanOne[*support.Parent],
+		},
+		{
+			"composite",
+			"schedules",
+			&support.Schedule{
+				Slot: &support.Slot{Id: 1, Seats: &support.IntRange{Lower: proto.Int64(1), Upper: proto.Int64(5)}},
+				Slots: []*support.Slot{
+					{Id: 2, Seats: &support.IntRange{Lower: proto.Int64(10)}},
+					{Id: 3, Seats: &support.IntRange{Empty: true}},
+				},
+			},
+			ColNames{"slot", "slots"},
+			[]pbpgx.Option{pbpgx.WithMessageEncoding(pbpgx.Composite)},
+			scanOne[*support.Schedule],
+		},
+		{
+			"range",
+			"bookings",
+			&support.Booking{
+				Id: 1,
+				Window: &support.TimeRange{
+					Lower:          &timestamppb.Timestamp{Seconds: 1641563227},
+					Upper:          &timestamppb.Timestamp{Seconds: 1641566827},
+					LowerInclusive: proto.Bool(true),
+					UpperInclusive: proto.Bool(false),
+				},
+				Days:  &support.DateRange{Lower: &date.Date{Year: 2022, Month: 1, Day: 7}},
+				Seats: &support.IntRange{Lower: proto.Int64(1), Upper: proto.Int64(5)},
+			},
+			ColNames{"id", "window", "days", "seats"},
+			nil,
+			scanOne[*support.Booking],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(testlib.CTX, time.Second)
+			defer cancel()
+
+			tx, err := testlib.ConnPool.Begin(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tx.Rollback(ctx)
+
+			args, err := Columns(nil).ParseArgs(tt.msg, tt.cols, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, len(tt.cols))
+			params := make([]string, len(tt.cols))
+			for i, col := range tt.cols {
+				names[i] = fmt.Sprintf("%q", col)
+				params[i] = fmt.Sprintf("$%d", i+1)
+			}
+			cols := strings.Join(names, ", ")
+
+			rows, err := tx.Query(ctx, fmt.Sprintf("insert into %s (%s) values (%s) returning %s;", tt.table, cols, strings.Join(params, ", "), cols), args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+
+			got, err := tt.scan(rows, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, tt.msg) {
+				t.Errorf("ScanOne() =\n%v\nwant\n%v", got, tt.msg)
+			}
+		})
+	}
+}
@@ -46,13 +46,13 @@ func (tab *Table[Col, Record, ID]) insertQuery(cols ColNames, returnColumns ...C
 func (tab *Table[Col, Record, ID]) CreateOne(ctx context.Context, x pbpgx.Executor, cols ColNames, data proto.Message, returnColumns ...Col) (record Record, err error) {
 	qs := tab.insertQuery(cols, returnColumns...)
 
-	args, err := tab.columns.ParseArgs(data, cols)
+	args, err := tab.parseArgs(data, cols)
 	if err != nil {
 		return record, fmt.Errorf("Table %s CreateOne: %w", tab.name(), err)
 	}
 
 	if len(returnColumns) > 0 {
-		record, err = pbpgx.QueryRow[Record](ctx, x, qs, args...)
+		record, err = tab.queryRow(ctx, x, qs, args...)
 	} else {
 		_, err = x.Exec(ctx, qs, args...)
 	}
@@ -95,7 +95,7 @@ func (tab *Table[Col, Record, ID]) Create(ctx context.Context, x pbpgx.Executor,
 	}
 
 	for i, m := range data {
-		args, err := tab.columns.ParseArgs(m, cols)
+		args, err := tab.parseArgs(m, cols)
 		if err != nil {
 			return records, fmt.Errorf("Table %s Create[%d]: %w", tab.name(), i, err)
 		}
@@ -103,7 +103,7 @@ func (tab *Table[Col, Record, ID]) Create(ctx context.Context, x pbpgx.Executor,
 		var record Record
 
 		if len(returnColumns) > 0 {
-			record, err = pbpgx.QueryRow[Record](ctx, x, qs, args...)
+			record, err = tab.queryRow(ctx, x, qs, args...)
 		} else {
 			_, err = x.Exec(ctx, qs, args...)
 		}
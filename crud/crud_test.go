@@ -21,10 +21,15 @@ package crud
 
 import (
 	"os"
+	"reflect"
 	"testing"
 
+	"github.com/muhlemmer/pbpgx"
 	"github.com/muhlemmer/pbpgx/internal/support"
 	"github.com/muhlemmer/pbpgx/internal/testlib"
+	"github.com/muhlemmer/pbpgx/query"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
 )
 
 var (
@@ -35,3 +40,96 @@ var (
 func TestMain(m *testing.M) {
 	os.Exit(testlib.TestMain(m))
 }
+
+func TestNewTable_tableOption(t *testing.T) {
+	tab := NewTable[support.LegacyColumns, *support.Legacy, int32]("public", "", nil)
+	if got, want := tab.name(), `"public"."legacy_rw"`; got != want {
+		t.Errorf("NewTable name = %s, want %s", got, want)
+	}
+}
+
+func TestNewDynamicTable(t *testing.T) {
+	tab := NewDynamicTable[support.LegacyColumns, int32]("public", "", (&support.Legacy{}).ProtoReflect().Descriptor(), nil)
+	if got, want := tab.name(), `"public"."legacy_rw"`; got != want {
+		t.Errorf("NewDynamicTable name = %s, want %s", got, want)
+	}
+}
+
+func TestTable_ParseFields(t *testing.T) {
+	tab := NewTable[support.SimpleColumns, *support.Supported, int32]("public", "supported", nil, pbpgx.WithNaming(pbpgx.LowerCamelCase))
+
+	got := tab.ParseFields(&support.Supported{OI32: proto.Int32(0), RI32: []int32{1}}, true)
+	want := ColNames{"rI32", "oI32"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.ParseFields() = %v, want %v", got, want)
+	}
+
+	got = tab.ParseFields(&support.Supported{OI32: proto.Int32(0), RI32: []int32{1}}, true, "r_i32", "oI32")
+	want = ColNames{"oI32"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.ParseFields() = %v, want %v", got, want)
+	}
+}
+
+func TestTable_idColumn(t *testing.T) {
+	type legacyTable = Table[support.LegacyColumns, *support.Legacy, int32]
+	cols := []support.LegacyColumns{support.LegacyColumns_legacy_title}
+
+	tests := []struct {
+		name string
+		qs   func(tab *legacyTable) string
+		want string
+	}{
+		{
+			"ReadOne",
+			func(tab *legacyTable) string {
+				return tab.selectQuery(cols, query.WhereColumnFunc[support.LegacyColumns](tab.id), nil, 1)
+			},
+			`SELECT "legacy_title" FROM "public"."legacy_rw" WHERE "legacy_id" = $1 LIMIT 1;`,
+		},
+		{
+			"ReadList",
+			func(tab *legacyTable) string {
+				return tab.selectQuery(cols, query.WhereColumnInFunc[support.LegacyColumns](tab.id, 2), nil, 0)
+			},
+			`SELECT "legacy_title" FROM "public"."legacy_rw" WHERE "legacy_id" IN ($1, $2);`,
+		},
+		{
+			"UpdateOne",
+			func(tab *legacyTable) string {
+				return tab.updateQuery(ColNames{"legacy_title"}, query.WhereColumnFunc[support.LegacyColumns](tab.id))
+			},
+			`UPDATE "public"."legacy_rw" SET "legacy_title" = $1 WHERE "legacy_id" = $2;`,
+		},
+		{
+			"DeleteOne",
+			func(tab *legacyTable) string {
+				return tab.deleteQuery(query.WhereColumnFunc[support.LegacyColumns](tab.id))
+			},
+			`DELETE FROM "public"."legacy_rw" WHERE "legacy_id" = $1;`,
+		},
+	}
+
+	tab := NewTable[support.LegacyColumns, *support.Legacy, int32]("public", "", nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.qs(tab); got != tt.want {
+				t.Errorf("%s query = %s, want %s", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	dyn := NewDynamicTable[support.LegacyColumns, int32]("public", "", (&support.Legacy{}).ProtoReflect().Descriptor(), nil)
+	if dyn.id != "legacy_id" {
+		t.Errorf("NewDynamicTable id = %s, want %s", dyn.id, "legacy_id")
+	}
+
+	simple := NewTable[support.SimpleColumns, *support.Simple, int32]("public", "simple_ro", nil, pbpgx.WithNaming(func(fd pr.FieldDescriptor) string {
+		return "simple_" + string(fd.Name())
+	}))
+	if simple.id != "simple_id" {
+		t.Errorf("NewTable id = %s, want %s", simple.id, "simple_id")
+	}
+}
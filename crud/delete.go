@@ -31,7 +31,7 @@ func (tab *Table[Col, Record, ID]) deleteQuery(wf query.WhereFunc[Col], returnCo
 	b := tab.pool.Get()
 	defer tab.pool.Put(b)
 
-	b.Delete(tab.schema, tab.table, query.WhereID[Col], returnColumns...)
+	b.Delete(tab.schema, tab.table, wf, returnColumns...)
 	return b.String()
 }
 
@@ -41,10 +41,10 @@ func (tab *Table[Col, Record, ID]) deleteQuery(wf query.WhereFunc[Col], returnCo
 // If any returnColumns are specified, the returned message will have the fields set as named by returnColumns.
 // If no returnColumns, the returned message will always be nil.
 func (tab *Table[Col, Record, ID]) DeleteOne(ctx context.Context, x pbpgx.Executor, id ID, returnColumns ...Col) (record Record, err error) {
-	qs := tab.deleteQuery(query.WhereID[Col], returnColumns...)
+	qs := tab.deleteQuery(query.WhereColumnFunc[Col](tab.id), returnColumns...)
 
 	if len(returnColumns) > 0 {
-		record, err = pbpgx.QueryRow[Record](ctx, x, qs, id)
+		record, err = tab.queryRow(ctx, x, qs, id)
 	} else {
 		_, err = x.Exec(ctx, qs, id)
 	}
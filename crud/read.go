@@ -39,7 +39,7 @@ func (tab *Table[Col, Record, ID]) selectQuery(columns []Col, wf query.WhereFunc
 // The returned message will be of type Record,
 // with the fields corresponding to columns populated.
 func (tab *Table[Col, Record, ID]) ReadOne(ctx context.Context, x pbpgx.Executor, id ID, columns []Col) (record Record, err error) {
-	record, err = pbpgx.QueryRow[Record](ctx, x, tab.selectQuery(columns, query.WhereID[Col], nil, 1), id)
+	record, err = tab.queryRow(ctx, x, tab.selectQuery(columns, query.WhereColumnFunc[Col](tab.id), nil, 1), id)
 	if err != nil {
 		return record, fmt.Errorf("Table %s ReadOne: %w", tab.name(), err)
 	}
@@ -51,7 +51,7 @@ func (tab *Table[Col, Record, ID]) ReadOne(ctx context.Context, x pbpgx.Executor
 // The returned messages will be a slice of type Record,
 // with the fields corresponding to columns populated.
 func (tab *Table[Col, Record, ID]) ReadAll(ctx context.Context, x pbpgx.Executor, limit int64, columns []Col, orderBy query.OrderWriter[Col]) ([]Record, error) {
-	records, err := pbpgx.Query[Record](ctx, x, tab.selectQuery(columns, nil, orderBy, limit))
+	records, err := tab.query(ctx, x, tab.selectQuery(columns, nil, orderBy, limit))
 	if err != nil {
 		return nil, fmt.Errorf("Table %s ReadAll: %w", tab.name(), err)
 	}
@@ -69,7 +69,7 @@ func (tab *Table[Col, Record, ID]) ReadList(ctx context.Context, x pbpgx.Executo
 		args[i] = id
 	}
 
-	records, err := pbpgx.Query[Record](ctx, x, tab.selectQuery(columns, query.WhereIDInFunc[Col](len(ids)), orderBy, 0), args...)
+	records, err := tab.query(ctx, x, tab.selectQuery(columns, query.WhereColumnInFunc[Col](tab.id, len(ids)), orderBy, 0), args...)
 	if err != nil {
 		return nil, fmt.Errorf("Table %s ReadList: %w", tab.name(), err)
 	}
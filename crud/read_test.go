@@ -74,6 +74,34 @@ func TestTable_ReadOne(t *testing.T) {
 
 }
 
+func TestTable_ReadOne_dynamic(t *testing.T) {
+	tab := NewDynamicTable[support.SimpleColumns, int32]("public", "simple_ro", (&support.Simple{}).ProtoReflect().Descriptor(), nil)
+
+	got, err := tab.ReadOne(testlib.CTX, testlib.ConnPool, 5, []support.SimpleColumns{
+		support.SimpleColumns_id,
+		support.SimpleColumns_title,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (&support.Simple{Id: 5, Title: "five"}); !proto.Equal(got, want) {
+		t.Errorf("Table.ReadOne() = %v, want %v", got, want)
+	}
+
+	if _, err = tab.ReadOne(testlib.CTX, testlib.ConnPool, 99, nil); err == nil {
+		t.Error("Table.ReadOne: expected error, got nil")
+	}
+
+	records, err := tab.ReadList(testlib.CTX, testlib.ConnPool, []int32{1, 2}, []support.SimpleColumns{support.SimpleColumns_id}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Table.ReadList() len = %d, want 2", len(records))
+	}
+}
+
 func TestTable_ReadAll(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -20,9 +20,16 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package crud
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/muhlemmer/pbpgx"
+	"github.com/muhlemmer/pbpgx/internal/value"
+	"github.com/muhlemmer/pbpgx/pbpgxpb"
 	"github.com/muhlemmer/pbpgx/query"
 	"golang.org/x/exp/constraints"
 	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type Enum interface {
@@ -37,7 +44,23 @@ type Table[Col Enum, Record proto.Message, ID constraints.Ordered] struct {
 	schema  string
 	table   string
 	columns Columns
+	opts    []pbpgx.Option
+	vo      *value.Options
 	pool    query.Pool[Col]
+	md      pr.MessageDescriptor // Set for dynamic records.
+	id      string               // Name of the id column.
+}
+
+// idColumn returns the column name of the "id" field in md,
+// following the (pbpgx.column) field option and the naming strategy set in vo.
+// If md has no "id" field, "id" is returned.
+func idColumn(md pr.MessageDescriptor, vo *value.Options) string {
+	fd := md.Fields().ByName("id")
+	if fd == nil {
+		return "id"
+	}
+
+	return vo.Column(fd)
 }
 
 // NewTable returns a newly allocated table.
@@ -48,17 +71,56 @@ type Table[Col Enum, Record proto.Message, ID constraints.Ordered] struct {
 //
 // The Col type parameter is typically an entry of a protocol buffers enum with columns names. (must implement the String() method).
 // The Record type parameter should be a protocol buffer message representing the databae schema.
-// Column names must match with field names, case sensitive.
+// Column names must match with field names, case sensitive,
+// or the names set with the (pbpgx.column) field option.
+// A different mapping can be set with the pbpgx.WithNaming and pbpgx.WithCaseInsensitive options.
 // It is recommended to define a field for each column, for usage with the wildcard operator '*'.
 // It is safe to have more fields than columns, the surplus will be ignored.
 // See pbpgx.Scan for details.
-// The ID type parameter should match the type used in "id" column of the table,
+// The ID type parameter should match the type used in the id column of the table,
 // used to match a single row in Read, Update and Delete.
-func NewTable[Col Enum, Record proto.Message, ID constraints.Ordered](schema, table string, cd Columns) *Table[Col, Record, ID] {
+// The id column name is taken from the "id" field of Record,
+// following the (pbpgx.column) field option and naming options.
+// Options are used for argument parsing and scanning of all queries on the table.
+// If table is an empty string, the (pbpgx.table) message option of Record is used as table name.
+func NewTable[Col Enum, Record proto.Message, ID constraints.Ordered](schema, table string, cd Columns, opts ...pbpgx.Option) *Table[Col, Record, ID] {
+	var record Record
+	md := record.ProtoReflect().Descriptor()
+	vo := valueOptions(opts)
+
+	if table == "" {
+		table = pbpgxpb.TableName(md)
+	}
+
 	return &Table[Col, Record, ID]{
 		schema:  schema,
 		table:   table,
 		columns: cd,
+		opts:    opts,
+		vo:      vo,
+		id:      idColumn(md, vo),
+	}
+}
+
+// NewDynamicTable returns a newly allocated table, for records described by md.
+// Records are returned as *dynamicpb.Message, which allows CRUD operations on
+// message types which are only known at runtime, for example from a descriptor registry.
+// See NewTable for the meaning of the other arguments.
+// If table is an empty string, the (pbpgx.table) message option of md is used as table name.
+func NewDynamicTable[Col Enum, ID constraints.Ordered](schema, table string, md pr.MessageDescriptor, cd Columns, opts ...pbpgx.Option) *Table[Col, proto.Message, ID] {
+	if table == "" {
+		table = pbpgxpb.TableName(md)
+	}
+	vo := valueOptions(opts)
+
+	return &Table[Col, proto.Message, ID]{
+		schema:  schema,
+		table:   table,
+		columns: cd,
+		opts:    opts,
+		vo:      vo,
+		md:      md,
+		id:      idColumn(md, vo),
 	}
 }
 
@@ -68,3 +130,57 @@ func (tab *Table[Col, Record, ID]) name() string {
 
 	return b.String()
 }
+
+// ParseFields is like the package level ParseFields,
+// returning column names following the pbpgx.NamingStrategy set in the options of the table.
+// Ignored names remain proto field names.
+func (tab *Table[Col, Record, ID]) ParseFields(msg proto.Message, skipEmpty bool, ignore ...string) ColNames {
+	return parseFields(tab.vo, msg, skipEmpty, ignore)
+}
+
+func (tab *Table[Col, Record, ID]) parseArgs(msg proto.Message, cols ColNames) ([]interface{}, error) {
+	return tab.columns.parseArgs(msg, cols, tab.vo)
+}
+
+// query is like pbpgx.Query, using the options of the table for scanning.
+func (tab *Table[Col, Record, ID]) query(ctx context.Context, x pbpgx.Executor, sql string, args ...interface{}) ([]Record, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rows, err := x.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	if tab.md != nil {
+		records, err := pbpgx.ScanDynamic(rows, tab.md, tab.opts...)
+		return interface{}(records).([]Record), err
+	}
+
+	return pbpgx.Scan[Record](rows, tab.opts...)
+}
+
+// queryRow is like pbpgx.QueryRow, using the options of the table for scanning.
+func (tab *Table[Col, Record, ID]) queryRow(ctx context.Context, x pbpgx.Executor, sql string, args ...interface{}) (Record, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rows, err := x.Query(ctx, sql, args...)
+	if err != nil {
+		var record Record
+		return record, fmt.Errorf("queryRow: %w", err)
+	}
+	defer rows.Close()
+
+	if tab.md != nil {
+		record, err := pbpgx.ScanOneDynamic(rows, tab.md, tab.opts...)
+		if err != nil {
+			var r Record
+			return r, err
+		}
+		return record.(Record), nil
+	}
+
+	return pbpgx.ScanOne[Record](rows, tab.opts...)
+}
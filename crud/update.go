@@ -45,16 +45,16 @@ func (tab *Table[Col, Record, ID]) updateQuery(cols ColNames, wf query.WhereFunc
 // If any returnColumns are specified, the returned message will have the fields set as named by returnColumns.
 // If no returnColumns, the returned message will always be nil.
 func (tab *Table[Col, Record, ID]) UpdateOne(ctx context.Context, x pbpgx.Executor, cols ColNames, id ID, data proto.Message, returnColumns ...Col) (record Record, err error) {
-	qs := tab.updateQuery(cols, query.WhereID[Col], returnColumns...)
+	qs := tab.updateQuery(cols, query.WhereColumnFunc[Col](tab.id), returnColumns...)
 
-	args, err := tab.columns.ParseArgs(data, cols)
+	args, err := tab.parseArgs(data, cols)
 	if err != nil {
 		return record, fmt.Errorf("Table %s UpdateOne: %w", tab.name(), err)
 	}
 	args = append(args, id)
 
 	if len(returnColumns) > 0 {
-		record, err = pbpgx.QueryRow[Record](ctx, x, qs, args...)
+		record, err = tab.queryRow(ctx, x, qs, args...)
 	} else {
 		_, err = x.Exec(ctx, qs, args...)
 	}
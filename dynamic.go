@@ -0,0 +1,89 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ScanDynamic returns a slice of messages described by md, filled with data from rows.
+// The messages are of type *dynamicpb.Message, which allows scanning into
+// message types which are only known at runtime, for example from a descriptor registry.
+// See Scan for field name matching rules and options.
+func ScanDynamic(rows pgx.Rows, md pr.MessageDescriptor, opts ...Option) ([]proto.Message, error) {
+	s, err := newScanner[proto.Message](rows, dynamicpb.NewMessageType(md), opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.scanAll()
+}
+
+// ScanOneDynamic returns a single message described by md, filled with data from rows.
+// pgx.ErrNoRows is returned when there are rows to scan.
+// See ScanDynamic for details.
+func ScanOneDynamic(rows pgx.Rows, md pr.MessageDescriptor, opts ...Option) (proto.Message, error) {
+	s, err := newScanner[proto.Message](rows, dynamicpb.NewMessageType(md), opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.scanOne()
+}
+
+// QueryDynamic runs the passed sql with args on the Executor x,
+// and returns a slice of messages described by md containing the results.
+// See ScanDynamic for more details.
+func QueryDynamic(ctx context.Context, x Executor, md pr.MessageDescriptor, sql string, args ...interface{}) ([]proto.Message, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rows, err := x.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pbpgx.QueryDynamic: %w", err)
+	}
+	defer rows.Close()
+
+	return ScanDynamic(rows, md)
+}
+
+// QueryRowDynamic runs the passed sql with args on the Executor x,
+// and returns one row Scanned into a message described by md.
+// See ScanDynamic for more details.
+//
+// In case of no rows, pgx.ErrNoRows is returned.
+func QueryRowDynamic(ctx context.Context, x Executor, md pr.MessageDescriptor, sql string, args ...interface{}) (proto.Message, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rows, err := x.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pbpgx.QueryRowDynamic: %w", err)
+	}
+	defer rows.Close()
+
+	return ScanOneDynamic(rows, md)
+}
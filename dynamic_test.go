@@ -0,0 +1,124 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestScanDynamic(t *testing.T) {
+	md := (&support.Supported{}).ProtoReflect().Descriptor()
+	names := []string{"i32", "s", "ts", "r_ts", "en", "w_s", "du", "dt", "o_i32"}
+
+	tests := []struct {
+		name    string
+		rows    [][]interface{}
+		want    []*support.Supported
+		wantErr bool
+	}{
+		{
+			"success",
+			[][]interface{}{
+				{
+					int32(1), "foo", time.Unix(12, 34), []time.Time{time.Unix(1, 0)}, int32(1), "bar",
+					90 * time.Second, time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC), int32(0),
+				},
+				{int32(2), nil, nil, nil, nil, nil, nil, nil, nil},
+			},
+			[]*support.Supported{
+				{
+					I32:  1,
+					S:    "foo",
+					Ts:   &timestamppb.Timestamp{Seconds: 12, Nanos: 34},
+					RTs:  []*timestamppb.Timestamp{{Seconds: 1}},
+					En:   support.SimpleColumns_title,
+					WS:   wrapperspb.String("bar"),
+					Du:   durationpb.New(90 * time.Second),
+					Dt:   &date.Date{Year: 2022, Month: 2, Day: 28},
+					OI32: proto.Int32(0),
+				},
+				{I32: 2},
+			},
+			false,
+		},
+		{
+			"scan error",
+			[][]interface{}{{1, 2}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScanDynamic(newTestRows(names, tt.rows), md)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ScanDynamic() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("ScanDynamic() =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			for i, want := range tt.want {
+				if _, ok := got[i].(*dynamicpb.Message); !ok {
+					t.Errorf("ScanDynamic() = %T, want %T", got[i], &dynamicpb.Message{})
+				}
+				if !proto.Equal(got[i], want) {
+					t.Errorf("ScanDynamic() =\n%s\nwant\n%s", got[i], want)
+				}
+			}
+		})
+	}
+
+	if _, err := ScanDynamic(newTestRows([]string{"foo"}, nil), md); err == nil {
+		t.Error("ScanDynamic: expected error, got nil")
+	}
+}
+
+func TestScanOneDynamic(t *testing.T) {
+	md := (&support.Simple{}).ProtoReflect().Descriptor()
+
+	got, err := ScanOneDynamic(newTestRows([]string{"id", "title"}, [][]interface{}{{int32(1), "foo"}}), md)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (&support.Simple{Id: 1, Title: "foo"}); !proto.Equal(got, want) {
+		t.Errorf("ScanOneDynamic() = %v, want %v", got, want)
+	}
+
+	if _, err = ScanOneDynamic(newTestRows([]string{"id"}, nil), md); err != pgx.ErrNoRows {
+		t.Errorf("ScanOneDynamic() error = %v, want %v", err, pgx.ErrNoRows)
+	}
+
+	if _, err = ScanOneDynamic(newTestRows([]string{"foo"}, nil), md); err == nil {
+		t.Error("ScanOneDynamic: expected error, got nil")
+	}
+}
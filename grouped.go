@@ -0,0 +1,211 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/muhlemmer/pbpgx/internal/value"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// childGroup holds the destinations of columns which are set
+// in an entry of a repeated message field.
+type childGroup struct {
+	fd      pr.FieldDescriptor
+	columns []int         // Indices of the columns in the result.
+	values  []value.Value // Values of the columns, relative to the entry message.
+}
+
+type groupScanner[M proto.Message] struct {
+	*scanner[M]
+	key      int   // Index of the key column.
+	parents  []int // Indices of the columns set in the parent message.
+	children []childGroup
+}
+
+func newGroupScanner[M proto.Message](rows pgx.Rows, key string, opts []Option) (*groupScanner[M], error) {
+	sc, err := newScanner[M](rows, messageType[M](), opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &groupScanner[M]{
+		scanner: sc,
+		key:     -1,
+	}
+
+	for i, f := range rows.FieldDescriptions() {
+		if string(f.Name) == key {
+			s.key = i
+		}
+
+		nv, ok := s.dest[i].(*nestedValue)
+		if !ok || !nv.path[0].IsList() {
+			s.parents = append(s.parents, i)
+			continue
+		}
+
+		var v value.Value = nv.Value
+		if len(nv.path) > 1 {
+			v = &nestedValue{nv.Value, nv.path[1:]}
+		}
+
+		s.addChild(nv.path[0], i, v)
+	}
+
+	if s.key < 0 {
+		return nil, fmt.Errorf("pbpgx.ScanGrouped: key column %s not in result", key)
+	}
+
+	return s, nil
+}
+
+func (s *groupScanner[M]) addChild(fd pr.FieldDescriptor, column int, v value.Value) {
+	for i := range s.children {
+		if s.children[i].fd == fd {
+			s.children[i].columns = append(s.children[i].columns, column)
+			s.children[i].values = append(s.children[i].values, v)
+			return
+		}
+	}
+
+	s.children = append(s.children, childGroup{
+		fd:      fd,
+		columns: []int{column},
+		values:  []value.Value{v},
+	})
+}
+
+// setParent sets the parent columns of the current row in msg,
+// and the null mask when NULL columns are tracked.
+func (s *groupScanner[M]) setParent(msg pr.Message) error {
+	for _, i := range s.parents {
+		if s.dest[i] == nil {
+			continue
+		}
+		if err := s.dest[i].(value.Value).SetTo(msg); err != nil {
+			return s.columnError(i, err)
+		}
+	}
+
+	// Child columns have no path, as they do not map to a field of a singular message.
+	if s.paths != nil {
+		s.setNulls(msg)
+	}
+
+	return nil
+}
+
+// appendChildren appends an entry to each repeated field of msg,
+// from the child columns of the current row.
+// Entries of which all columns are NULL are not appended.
+func (s *groupScanner[M]) appendChildren(msg pr.Message) error {
+	for _, child := range s.children {
+		present := false
+		for _, v := range child.values {
+			if !isNull(v) {
+				present = true
+				break
+			}
+		}
+		if !present {
+			continue
+		}
+
+		list := msg.Mutable(child.fd).List()
+		entry := list.NewElement()
+
+		for j, v := range child.values {
+			if err := v.SetTo(entry.Message()); err != nil {
+				return s.columnError(child.columns[j], err)
+			}
+		}
+
+		list.Append(entry)
+	}
+
+	return nil
+}
+
+// ScanGrouped returns a slice of proto messages of type M, filled with data from rows,
+// where consecutive rows with the same value in the key column are collapsed into one message.
+// This is typically used for queries which join a parent table with a child table.
+//
+// Columns prefixed with the name of a repeated message field, such as "items.sku" or "items__sku",
+// are set in a new entry of that field, for every row in the group.
+// No entry is appended when all its columns are NULL,
+// as is the case for a parent without children in a LEFT JOIN.
+// Other columns are set from the first row of the group.
+//
+// With the WithNullMask option, the null mask holds the parent columns
+// which are NULL in the first row of the group.
+// NULL columns of the entries of repeated fields are not tracked.
+//
+// Rows must be ordered by the key column, for example with an ORDER BY clause.
+// The key column is compared by its raw value and must be in the result.
+// See Scan for field name matching rules and options.
+func ScanGrouped[M proto.Message](rows pgx.Rows, key string, opts ...Option) (result []M, err error) {
+	s, err := newGroupScanner[M](rows, key, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		msg      pr.Message
+		lastKey  []byte
+		lastNull bool
+	)
+
+	for s.rows.Next() {
+		if err := s.rows.Scan(s.dest...); err != nil {
+			return nil, fmt.Errorf("pbpgx.ScanGrouped into proto.Message %s: %w", s.typeName(), err)
+		}
+
+		rowKey := s.rows.RawValues()[s.key]
+
+		if msg == nil || (rowKey == nil) != lastNull || !bytes.Equal(rowKey, lastKey) {
+			if msg != nil {
+				result = append(result, msg.Interface().(M))
+			}
+
+			msg = s.msg.New()
+			lastKey = append(lastKey[:0], rowKey...)
+			lastNull = rowKey == nil
+
+			if err := s.setParent(msg); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.appendChildren(msg); err != nil {
+			return nil, err
+		}
+	}
+
+	if msg != nil {
+		result = append(result, msg.Interface().(M))
+	}
+
+	return result, s.rows.Err()
+}
@@ -0,0 +1,177 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"testing"
+
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestScanGrouped(t *testing.T) {
+	names := []string{"id", "title", "children.id", "children__name", "children.born.seconds", "favorite.name"}
+
+	tests := []struct {
+		name    string
+		key     string
+		rows    [][]interface{}
+		want    []*support.Parent
+		wantErr bool
+	}{
+		{
+			"no rows",
+			"id",
+			nil,
+			nil,
+			false,
+		},
+		{
+			"grouped",
+			"id",
+			[][]interface{}{
+				{int32(1), "one", int32(10), "foo", int64(5), "foo"},
+				{int32(1), "one", int32(11), "bar", nil, "foo"},
+				{int32(2), "two", nil, nil, nil, nil},
+				{int32(3), "three", int32(12), "", nil, nil},
+			},
+			[]*support.Parent{
+				{
+					Id:    1,
+					Title: "one",
+					Children: []*support.Child{
+						{Id: 10, Name: "foo", Born: &timestamppb.Timestamp{Seconds: 5}},
+						{Id: 11, Name: "bar"},
+					},
+					Favorite: &support.Child{Name: "foo"},
+				},
+				{
+					Id:    2,
+					Title: "two",
+				},
+				{
+					Id:    3,
+					Title: "three",
+					Children: []*support.Child{
+						{Id: 12},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"null key",
+			"title",
+			[][]interface{}{
+				{int32(1), nil, int32(10), "foo", nil, nil},
+				{int32(1), nil, int32(11), "bar", nil, nil},
+				{int32(2), "", nil, nil, nil, nil},
+			},
+			[]*support.Parent{
+				{
+					Id: 1,
+					Children: []*support.Child{
+						{Id: 10, Name: "foo"},
+						{Id: 11, Name: "bar"},
+					},
+				},
+				{
+					Id: 2,
+				},
+			},
+			false,
+		},
+		{
+			"missing key",
+			"foo",
+			nil,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScanGrouped[*support.Parent](newTestRows(names, tt.rows), tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ScanGrouped() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("ScanGrouped() =\n%v\nwant\n%v", got, tt.want)
+			}
+			for i := range tt.want {
+				if !proto.Equal(got[i], tt.want[i]) {
+					t.Errorf("ScanGrouped()[%d] =\n%v\nwant\n%v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScan_repeatedPrefix(t *testing.T) {
+	_, err := Scan[*support.Parent](newTestRows([]string{"children.id"}, [][]interface{}{{int32(1)}}))
+	if err == nil {
+		t.Error("Scan: expected error, got nil")
+	}
+}
+
+func TestScanGrouped_nullMask(t *testing.T) {
+	names := []string{"id", "title", "children.id", "children.name", "favorite.name"}
+	rows := [][]interface{}{
+		{int32(1), nil, int32(10), nil, nil},
+		{int32(1), "one", int32(11), "bar", "foo"},
+		{int32(2), "two", nil, nil, "baz"},
+	}
+
+	got, err := ScanGrouped[*support.Parent](newTestRows(names, rows), "id", WithNullMask("nulls"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*support.Parent{
+		{
+			Id: 1,
+			Children: []*support.Child{
+				{Id: 10},
+				{Id: 11, Name: "bar"},
+			},
+			Nulls: &fieldmaskpb.FieldMask{Paths: []string{"title", "favorite.name"}},
+		},
+		{
+			Id:       2,
+			Title:    "two",
+			Favorite: &support.Child{Name: "baz"},
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ScanGrouped() =\n%v\nwant\n%v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("ScanGrouped()[%d] =\n%v\nwant\n%v", i, got[i], want[i])
+		}
+	}
+
+	if _, err = ScanGrouped[*support.Parent](newTestRows(names, rows), "id", WithNullMask("title")); err == nil {
+		t.Error("ScanGrouped() with null mask title: expected error, got nil")
+	}
+}
@@ -25,9 +25,19 @@
 package support
 
 import (
+	_ "github.com/muhlemmer/pbpgx/pbpgxpb"
+	date "google.golang.org/genproto/googleapis/type/date"
+	decimal "google.golang.org/genproto/googleapis/type/decimal"
+	latlng "google.golang.org/genproto/googleapis/type/latlng"
+	money "google.golang.org/genproto/googleapis/type/money"
+	timeofday "google.golang.org/genproto/googleapis/type/timeofday"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -91,6 +101,55 @@ func (SimpleColumns) EnumDescriptor() ([]byte, []int) {
 	return file_support_proto_rawDescGZIP(), []int{0}
 }
 
+type LegacyColumns int32
+
+const (
+	LegacyColumns_legacy_id    LegacyColumns = 0
+	LegacyColumns_legacy_title LegacyColumns = 1
+	LegacyColumns_legacy_data  LegacyColumns = 2
+)
+
+// Enum value maps for LegacyColumns.
+var (
+	LegacyColumns_name = map[int32]string{
+		0: "legacy_id",
+		1: "legacy_title",
+		2: "legacy_data",
+	}
+	LegacyColumns_value = map[string]int32{
+		"legacy_id":    0,
+		"legacy_title": 1,
+		"legacy_data":  2,
+	}
+)
+
+func (x LegacyColumns) Enum() *LegacyColumns {
+	p := new(LegacyColumns)
+	*p = x
+	return p
+}
+
+func (x LegacyColumns) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LegacyColumns) Descriptor() protoreflect.EnumDescriptor {
+	return file_support_proto_enumTypes[1].Descriptor()
+}
+
+func (LegacyColumns) Type() protoreflect.EnumType {
+	return &file_support_proto_enumTypes[1]
+}
+
+func (x LegacyColumns) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LegacyColumns.Descriptor instead.
+func (LegacyColumns) EnumDescriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{1}
+}
+
 // Supported destination types
 type Supported struct {
 	state         protoimpl.MessageState
@@ -120,7 +179,41 @@ type Supported struct {
 	// Types that are assignable to O:
 	//	*Supported_Ob
 	//	*Supported_Oi
-	O isSupported_O `protobuf_oneof:"o"`
+	O    isSupported_O                    `protobuf_oneof:"o"`
+	En   SimpleColumns                    `protobuf:"varint,23,opt,name=en,proto3,enum=support.SimpleColumns" json:"en,omitempty"`
+	REn  []SimpleColumns                  `protobuf:"varint,24,rep,packed,name=r_en,json=rEn,proto3,enum=support.SimpleColumns" json:"r_en,omitempty"`
+	Mp   map[string]int32                 `protobuf:"bytes,25,rep,name=mp,proto3" json:"mp,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	TsMp map[int32]*timestamppb.Timestamp `protobuf:"bytes,26,rep,name=ts_mp,json=tsMp,proto3" json:"ts_mp,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SMp  map[string]string                `protobuf:"bytes,27,rep,name=s_mp,json=sMp,proto3" json:"s_mp,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	WBl  *wrapperspb.BoolValue            `protobuf:"bytes,28,opt,name=w_bl,json=wBl,proto3" json:"w_bl,omitempty"`
+	WI32 *wrapperspb.Int32Value           `protobuf:"bytes,29,opt,name=w_i32,json=wI32,proto3" json:"w_i32,omitempty"`
+	WI64 *wrapperspb.Int64Value           `protobuf:"bytes,30,opt,name=w_i64,json=wI64,proto3" json:"w_i64,omitempty"`
+	WF   *wrapperspb.FloatValue           `protobuf:"bytes,31,opt,name=w_f,json=wF,proto3" json:"w_f,omitempty"`
+	WD   *wrapperspb.DoubleValue          `protobuf:"bytes,32,opt,name=w_d,json=wD,proto3" json:"w_d,omitempty"`
+	WS   *wrapperspb.StringValue          `protobuf:"bytes,33,opt,name=w_s,json=wS,proto3" json:"w_s,omitempty"`
+	WBt  *wrapperspb.BytesValue           `protobuf:"bytes,34,opt,name=w_bt,json=wBt,proto3" json:"w_bt,omitempty"`
+	WU32 *wrapperspb.UInt32Value          `protobuf:"bytes,35,opt,name=w_u32,json=wU32,proto3" json:"w_u32,omitempty"`
+	WU64 *wrapperspb.UInt64Value          `protobuf:"bytes,36,opt,name=w_u64,json=wU64,proto3" json:"w_u64,omitempty"`
+	Du   *durationpb.Duration             `protobuf:"bytes,37,opt,name=du,proto3" json:"du,omitempty"`
+	RDu  []*durationpb.Duration           `protobuf:"bytes,38,rep,name=r_du,json=rDu,proto3" json:"r_du,omitempty"`
+	St   *structpb.Struct                 `protobuf:"bytes,39,opt,name=st,proto3" json:"st,omitempty"`
+	Val  *structpb.Value                  `protobuf:"bytes,40,opt,name=val,proto3" json:"val,omitempty"`
+	Lv   *structpb.ListValue              `protobuf:"bytes,41,opt,name=lv,proto3" json:"lv,omitempty"`
+	RSt  []*structpb.Struct               `protobuf:"bytes,42,rep,name=r_st,json=rSt,proto3" json:"r_st,omitempty"`
+	Dt   *date.Date                       `protobuf:"bytes,43,opt,name=dt,proto3" json:"dt,omitempty"`
+	Tod  *timeofday.TimeOfDay             `protobuf:"bytes,44,opt,name=tod,proto3" json:"tod,omitempty"`
+	Ll   *latlng.LatLng                   `protobuf:"bytes,45,opt,name=ll,proto3" json:"ll,omitempty"`
+	Dec  *decimal.Decimal                 `protobuf:"bytes,46,opt,name=dec,proto3" json:"dec,omitempty"`
+	Mon  *money.Money                     `protobuf:"bytes,47,opt,name=mon,proto3" json:"mon,omitempty"`
+	RDt  []*date.Date                     `protobuf:"bytes,48,rep,name=r_dt,json=rDt,proto3" json:"r_dt,omitempty"`
+	RTod []*timeofday.TimeOfDay           `protobuf:"bytes,49,rep,name=r_tod,json=rTod,proto3" json:"r_tod,omitempty"`
+	RLl  []*latlng.LatLng                 `protobuf:"bytes,50,rep,name=r_ll,json=rLl,proto3" json:"r_ll,omitempty"`
+	RDec []*decimal.Decimal               `protobuf:"bytes,51,rep,name=r_dec,json=rDec,proto3" json:"r_dec,omitempty"`
+	RMon []*money.Money                   `protobuf:"bytes,52,rep,name=r_mon,json=rMon,proto3" json:"r_mon,omitempty"`
+	OI32 *int32                           `protobuf:"varint,53,opt,name=o_i32,json=oI32,proto3,oneof" json:"o_i32,omitempty"`
+	OS   *string                          `protobuf:"bytes,54,opt,name=o_s,json=oS,proto3,oneof" json:"o_s,omitempty"`
+	OEn  *SimpleColumns                   `protobuf:"varint,55,opt,name=o_en,json=oEn,proto3,enum=support.SimpleColumns,oneof" json:"o_en,omitempty"`
+	OTs  *timestamppb.Timestamp           `protobuf:"bytes,56,opt,name=o_ts,json=oTs,proto3,oneof" json:"o_ts,omitempty"`
 }
 
 func (x *Supported) Reset() {
@@ -316,200 +409,287 @@ func (x *Supported) GetOi() int32 {
 	return 0
 }
 
-type isSupported_O interface {
-	isSupported_O()
+func (x *Supported) GetEn() SimpleColumns {
+	if x != nil {
+		return x.En
+	}
+	return SimpleColumns_id
 }
 
-type Supported_Ob struct {
-	Ob bool `protobuf:"varint,21,opt,name=ob,proto3,oneof"`
+func (x *Supported) GetREn() []SimpleColumns {
+	if x != nil {
+		return x.REn
+	}
+	return nil
 }
 
-type Supported_Oi struct {
-	Oi int32 `protobuf:"varint,22,opt,name=oi,proto3,oneof"`
+func (x *Supported) GetMp() map[string]int32 {
+	if x != nil {
+		return x.Mp
+	}
+	return nil
 }
 
-func (*Supported_Ob) isSupported_O() {}
+func (x *Supported) GetTsMp() map[int32]*timestamppb.Timestamp {
+	if x != nil {
+		return x.TsMp
+	}
+	return nil
+}
 
-func (*Supported_Oi) isSupported_O() {}
+func (x *Supported) GetSMp() map[string]string {
+	if x != nil {
+		return x.SMp
+	}
+	return nil
+}
 
-// Unsupported scan destination types (for now)
-type Unsupported struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Supported) GetWBl() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.WBl
+	}
+	return nil
+}
 
-	Sup  *Supported                       `protobuf:"bytes,1,opt,name=sup,proto3" json:"sup,omitempty"` // Nested, unregisterd messages
-	Mp   map[string]int32                 `protobuf:"bytes,2,rep,name=mp,proto3" json:"mp,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	TsMp map[int32]*timestamppb.Timestamp `protobuf:"bytes,3,rep,name=ts_mp,json=tsMp,proto3" json:"ts_mp,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	En   SimpleColumns                    `protobuf:"varint,4,opt,name=en,proto3,enum=support.SimpleColumns" json:"en,omitempty"`
-	REn  []SimpleColumns                  `protobuf:"varint,5,rep,packed,name=r_en,json=rEn,proto3,enum=support.SimpleColumns" json:"r_en,omitempty"`
+func (x *Supported) GetWI32() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.WI32
+	}
+	return nil
 }
 
-func (x *Unsupported) Reset() {
-	*x = Unsupported{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_support_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Supported) GetWI64() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.WI64
 	}
+	return nil
 }
 
-func (x *Unsupported) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Supported) GetWF() *wrapperspb.FloatValue {
+	if x != nil {
+		return x.WF
+	}
+	return nil
 }
 
-func (*Unsupported) ProtoMessage() {}
+func (x *Supported) GetWD() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.WD
+	}
+	return nil
+}
 
-func (x *Unsupported) ProtoReflect() protoreflect.Message {
-	mi := &file_support_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Supported) GetWS() *wrapperspb.StringValue {
+	if x != nil {
+		return x.WS
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use Unsupported.ProtoReflect.Descriptor instead.
-func (*Unsupported) Descriptor() ([]byte, []int) {
-	return file_support_proto_rawDescGZIP(), []int{1}
+func (x *Supported) GetWBt() *wrapperspb.BytesValue {
+	if x != nil {
+		return x.WBt
+	}
+	return nil
 }
 
-func (x *Unsupported) GetSup() *Supported {
+func (x *Supported) GetWU32() *wrapperspb.UInt32Value {
 	if x != nil {
-		return x.Sup
+		return x.WU32
 	}
 	return nil
 }
 
-func (x *Unsupported) GetMp() map[string]int32 {
+func (x *Supported) GetWU64() *wrapperspb.UInt64Value {
 	if x != nil {
-		return x.Mp
+		return x.WU64
 	}
 	return nil
 }
 
-func (x *Unsupported) GetTsMp() map[int32]*timestamppb.Timestamp {
+func (x *Supported) GetDu() *durationpb.Duration {
 	if x != nil {
-		return x.TsMp
+		return x.Du
 	}
 	return nil
 }
 
-func (x *Unsupported) GetEn() SimpleColumns {
+func (x *Supported) GetRDu() []*durationpb.Duration {
 	if x != nil {
-		return x.En
+		return x.RDu
 	}
-	return SimpleColumns_id
+	return nil
 }
 
-func (x *Unsupported) GetREn() []SimpleColumns {
+func (x *Supported) GetSt() *structpb.Struct {
 	if x != nil {
-		return x.REn
+		return x.St
 	}
 	return nil
 }
 
-// Simple is used for unit testing
-type Simple struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Supported) GetVal() *structpb.Value {
+	if x != nil {
+		return x.Val
+	}
+	return nil
+}
 
-	Id      int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title   string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Data    string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	Created *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+func (x *Supported) GetLv() *structpb.ListValue {
+	if x != nil {
+		return x.Lv
+	}
+	return nil
 }
 
-func (x *Simple) Reset() {
-	*x = Simple{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_support_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Supported) GetRSt() []*structpb.Struct {
+	if x != nil {
+		return x.RSt
 	}
+	return nil
 }
 
-func (x *Simple) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Supported) GetDt() *date.Date {
+	if x != nil {
+		return x.Dt
+	}
+	return nil
 }
 
-func (*Simple) ProtoMessage() {}
+func (x *Supported) GetTod() *timeofday.TimeOfDay {
+	if x != nil {
+		return x.Tod
+	}
+	return nil
+}
 
-func (x *Simple) ProtoReflect() protoreflect.Message {
-	mi := &file_support_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Supported) GetLl() *latlng.LatLng {
+	if x != nil {
+		return x.Ll
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use Simple.ProtoReflect.Descriptor instead.
-func (*Simple) Descriptor() ([]byte, []int) {
-	return file_support_proto_rawDescGZIP(), []int{2}
+func (x *Supported) GetDec() *decimal.Decimal {
+	if x != nil {
+		return x.Dec
+	}
+	return nil
 }
 
-func (x *Simple) GetId() int32 {
+func (x *Supported) GetMon() *money.Money {
 	if x != nil {
-		return x.Id
+		return x.Mon
 	}
-	return 0
+	return nil
 }
 
-func (x *Simple) GetTitle() string {
+func (x *Supported) GetRDt() []*date.Date {
 	if x != nil {
-		return x.Title
+		return x.RDt
 	}
-	return ""
+	return nil
 }
 
-func (x *Simple) GetData() string {
+func (x *Supported) GetRTod() []*timeofday.TimeOfDay {
 	if x != nil {
-		return x.Data
+		return x.RTod
+	}
+	return nil
+}
+
+func (x *Supported) GetRLl() []*latlng.LatLng {
+	if x != nil {
+		return x.RLl
+	}
+	return nil
+}
+
+func (x *Supported) GetRDec() []*decimal.Decimal {
+	if x != nil {
+		return x.RDec
+	}
+	return nil
+}
+
+func (x *Supported) GetRMon() []*money.Money {
+	if x != nil {
+		return x.RMon
+	}
+	return nil
+}
+
+func (x *Supported) GetOI32() int32 {
+	if x != nil && x.OI32 != nil {
+		return *x.OI32
+	}
+	return 0
+}
+
+func (x *Supported) GetOS() string {
+	if x != nil && x.OS != nil {
+		return *x.OS
 	}
 	return ""
 }
 
-func (x *Simple) GetCreated() *timestamppb.Timestamp {
+func (x *Supported) GetOEn() SimpleColumns {
+	if x != nil && x.OEn != nil {
+		return *x.OEn
+	}
+	return SimpleColumns_id
+}
+
+func (x *Supported) GetOTs() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Created
+		return x.OTs
 	}
 	return nil
 }
 
-type SimpleQuery struct {
+type isSupported_O interface {
+	isSupported_O()
+}
+
+type Supported_Ob struct {
+	Ob bool `protobuf:"varint,21,opt,name=ob,proto3,oneof"`
+}
+
+type Supported_Oi struct {
+	Oi int32 `protobuf:"varint,22,opt,name=oi,proto3,oneof"`
+}
+
+func (*Supported_Ob) isSupported_O() {}
+
+func (*Supported_Oi) isSupported_O() {}
+
+// Unsupported scan destination types (for now)
+type Unsupported struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      int32           `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Columns []SimpleColumns `protobuf:"varint,2,rep,packed,name=columns,proto3,enum=support.SimpleColumns" json:"columns,omitempty"`
+	Sup   *Supported               `protobuf:"bytes,1,opt,name=sup,proto3" json:"sup,omitempty"` // Nested, unregisterd messages
+	RWI64 []*wrapperspb.Int64Value `protobuf:"bytes,2,rep,name=r_w_i64,json=rWI64,proto3" json:"r_w_i64,omitempty"`
 }
 
-func (x *SimpleQuery) Reset() {
-	*x = SimpleQuery{}
+func (x *Unsupported) Reset() {
+	*x = Unsupported{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_support_proto_msgTypes[3]
+		mi := &file_support_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SimpleQuery) String() string {
+func (x *Unsupported) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SimpleQuery) ProtoMessage() {}
+func (*Unsupported) ProtoMessage() {}
 
-func (x *SimpleQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_support_proto_msgTypes[3]
+func (x *Unsupported) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,104 +700,1620 @@ func (x *SimpleQuery) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SimpleQuery.ProtoReflect.Descriptor instead.
-func (*SimpleQuery) Descriptor() ([]byte, []int) {
-	return file_support_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Unsupported.ProtoReflect.Descriptor instead.
+func (*Unsupported) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *SimpleQuery) GetId() int32 {
+func (x *Unsupported) GetSup() *Supported {
 	if x != nil {
-		return x.Id
+		return x.Sup
 	}
-	return 0
+	return nil
 }
 
-func (x *SimpleQuery) GetColumns() []SimpleColumns {
+func (x *Unsupported) GetRWI64() []*wrapperspb.Int64Value {
 	if x != nil {
-		return x.Columns
+		return x.RWI64
 	}
 	return nil
 }
 
-var File_support_proto protoreflect.FileDescriptor
+// Custom message type, registered during unit testing.
+type Custom struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_support_proto_rawDesc = []byte{
-	0x0a, 0x0d, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x07, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xce, 0x03, 0x0a, 0x09, 0x53, 0x75,
-	0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x62, 0x6c, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x02, 0x62, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x33, 0x32, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x69, 0x33, 0x32, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x36, 0x34,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x69, 0x36, 0x34, 0x12, 0x0c, 0x0a, 0x01, 0x66,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x66, 0x12, 0x0c, 0x0a, 0x01, 0x64, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x01, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x62, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x02, 0x62, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x33, 0x32, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x03, 0x75, 0x33, 0x32, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x36, 0x34, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x75, 0x36, 0x34, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x73, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x02, 0x74, 0x73, 0x12, 0x11, 0x0a, 0x04, 0x72, 0x5f, 0x62, 0x6c, 0x18, 0x0b, 0x20,
-	0x03, 0x28, 0x08, 0x52, 0x03, 0x72, 0x42, 0x6c, 0x12, 0x13, 0x0a, 0x05, 0x72, 0x5f, 0x69, 0x33,
-	0x32, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x72, 0x49, 0x33, 0x32, 0x12, 0x13, 0x0a,
-	0x05, 0x72, 0x5f, 0x69, 0x36, 0x34, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x72, 0x49,
-	0x36, 0x34, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x66, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x02, 0x52,
-	0x02, 0x72, 0x46, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x64, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x01,
-	0x52, 0x02, 0x72, 0x44, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x02, 0x72, 0x53, 0x12, 0x13, 0x0a, 0x05, 0x72, 0x5f, 0x75, 0x33, 0x32, 0x18, 0x11,
-	0x20, 0x03, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x55, 0x33, 0x32, 0x12, 0x11, 0x0a, 0x04, 0x72, 0x5f,
-	0x62, 0x74, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x42, 0x74, 0x12, 0x13, 0x0a,
-	0x05, 0x72, 0x5f, 0x75, 0x36, 0x34, 0x18, 0x13, 0x20, 0x03, 0x28, 0x04, 0x52, 0x04, 0x72, 0x55,
-	0x36, 0x34, 0x12, 0x2d, 0x0a, 0x04, 0x72, 0x5f, 0x74, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x72, 0x54,
-	0x73, 0x12, 0x10, 0x0a, 0x02, 0x6f, 0x62, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
-	0x02, 0x6f, 0x62, 0x12, 0x10, 0x0a, 0x02, 0x6f, 0x69, 0x18, 0x16, 0x20, 0x01, 0x28, 0x05, 0x48,
-	0x00, 0x52, 0x02, 0x6f, 0x69, 0x42, 0x03, 0x0a, 0x01, 0x6f, 0x22, 0xf5, 0x02, 0x0a, 0x0b, 0x55,
-	0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x03, 0x73, 0x75,
-	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72,
-	0x74, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52, 0x03, 0x73, 0x75, 0x70,
-	0x12, 0x2c, 0x0a, 0x02, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73,
-	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x55, 0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
-	0x65, 0x64, 0x2e, 0x4d, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x02, 0x6d, 0x70, 0x12, 0x33,
-	0x0a, 0x05, 0x74, 0x73, 0x5f, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
-	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x55, 0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72,
-	0x74, 0x65, 0x64, 0x2e, 0x54, 0x73, 0x4d, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74,
-	0x73, 0x4d, 0x70, 0x12, 0x26, 0x0a, 0x02, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x16, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65,
-	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x02, 0x65, 0x6e, 0x12, 0x29, 0x0a, 0x04, 0x72,
-	0x5f, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x73, 0x75, 0x70, 0x70,
-	0x6f, 0x72, 0x74, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
-	0x73, 0x52, 0x03, 0x72, 0x45, 0x6e, 0x1a, 0x35, 0x0a, 0x07, 0x4d, 0x70, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x53, 0x0a,
-	0x09, 0x54, 0x73, 0x4d, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x22, 0x78, 0x0a, 0x06, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
-	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74,
-	0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Custom) Reset() {
+	*x = Custom{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Custom) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Custom) ProtoMessage() {}
+
+func (x *Custom) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Custom.ProtoReflect.Descriptor instead.
+func (*Custom) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Custom) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Registered scan destination types, after registration during unit testing.
+type Registered struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cst  *Custom   `protobuf:"bytes,1,opt,name=cst,proto3" json:"cst,omitempty"`
+	RCst []*Custom `protobuf:"bytes,2,rep,name=r_cst,json=rCst,proto3" json:"r_cst,omitempty"`
+}
+
+func (x *Registered) Reset() {
+	*x = Registered{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Registered) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Registered) ProtoMessage() {}
+
+func (x *Registered) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Registered.ProtoReflect.Descriptor instead.
+func (*Registered) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Registered) GetCst() *Custom {
+	if x != nil {
+		return x.Cst
+	}
+	return nil
+}
+
+func (x *Registered) GetRCst() []*Custom {
+	if x != nil {
+		return x.RCst
+	}
+	return nil
+}
+
+// Simple is used for unit testing
+type Simple struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title   string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Data    string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Created *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+}
+
+func (x *Simple) Reset() {
+	*x = Simple{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Simple) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Simple) ProtoMessage() {}
+
+func (x *Simple) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Simple.ProtoReflect.Descriptor instead.
+func (*Simple) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Simple) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Simple) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Simple) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *Simple) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type SimpleQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      int32           `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Columns []SimpleColumns `protobuf:"varint,2,rep,packed,name=columns,proto3,enum=support.SimpleColumns" json:"columns,omitempty"`
+}
+
+func (x *SimpleQuery) Reset() {
+	*x = SimpleQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SimpleQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimpleQuery) ProtoMessage() {}
+
+func (x *SimpleQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimpleQuery.ProtoReflect.Descriptor instead.
+func (*SimpleQuery) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SimpleQuery) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SimpleQuery) GetColumns() []SimpleColumns {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+// Legacy is used for unit testing of table and column name options.
+type Legacy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Data  string `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Legacy) Reset() {
+	*x = Legacy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Legacy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Legacy) ProtoMessage() {}
+
+func (x *Legacy) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Legacy.ProtoReflect.Descriptor instead.
+func (*Legacy) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Legacy) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Legacy) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Legacy) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+// Parent is used for unit testing of grouped scanning.
+type Parent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title    string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Children []*Child               `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	Favorite *Child                 `protobuf:"bytes,4,opt,name=favorite,proto3" json:"favorite,omitempty"`
+	Nulls    *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=nulls,proto3" json:"nulls,omitempty"`
+}
+
+func (x *Parent) Reset() {
+	*x = Parent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Parent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parent) ProtoMessage() {}
+
+func (x *Parent) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parent.ProtoReflect.Descriptor instead.
+func (*Parent) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Parent) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Parent) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Parent) GetChildren() []*Child {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *Parent) GetFavorite() *Child {
+	if x != nil {
+		return x.Favorite
+	}
+	return nil
+}
+
+func (x *Parent) GetNulls() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.Nulls
+	}
+	return nil
+}
+
+// Child is used for unit testing of grouped scanning.
+type Child struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Born *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=born,proto3" json:"born,omitempty"`
+}
+
+func (x *Child) Reset() {
+	*x = Child{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Child) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Child) ProtoMessage() {}
+
+func (x *Child) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Child.ProtoReflect.Descriptor instead.
+func (*Child) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Child) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Child) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Child) GetBorn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Born
+	}
+	return nil
+}
+
+// Tracked is used for unit testing of NULL tracking.
+type Tracked struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Child *Child                 `protobuf:"bytes,3,opt,name=child,proto3" json:"child,omitempty"`
+	Nulls *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=nulls,proto3" json:"nulls,omitempty"`
+}
+
+func (x *Tracked) Reset() {
+	*x = Tracked{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tracked) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tracked) ProtoMessage() {}
+
+func (x *Tracked) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tracked.ProtoReflect.Descriptor instead.
+func (*Tracked) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Tracked) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tracked) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Tracked) GetChild() *Child {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+func (x *Tracked) GetNulls() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.Nulls
+	}
+	return nil
+}
+
+// Grid is used for unit testing of multi-dimensional arrays.
+type Grid struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     int32    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Rows   []*Row   `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	Planes []*Plane `protobuf:"bytes,3,rep,name=planes,proto3" json:"planes,omitempty"`
+	Tags   []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *Grid) Reset() {
+	*x = Grid{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Grid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Grid) ProtoMessage() {}
+
+func (x *Grid) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Grid.ProtoReflect.Descriptor instead.
+func (*Grid) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Grid) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Grid) GetRows() []*Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *Grid) GetPlanes() []*Plane {
+	if x != nil {
+		return x.Planes
+	}
+	return nil
+}
+
+func (x *Grid) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Row of a two dimensional array.
+type Row struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cells []int32 `protobuf:"varint,1,rep,packed,name=cells,proto3" json:"cells,omitempty"`
+}
+
+func (x *Row) Reset() {
+	*x = Row{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Row) ProtoMessage() {}
+
+func (x *Row) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Row.ProtoReflect.Descriptor instead.
+func (*Row) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Row) GetCells() []int32 {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+// Plane of a three dimensional array.
+type Plane struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows []*Row `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *Plane) Reset() {
+	*x = Plane{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Plane) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plane) ProtoMessage() {}
+
+func (x *Plane) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plane.ProtoReflect.Descriptor instead.
+func (*Plane) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Plane) GetRows() []*Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// Booking is used for unit testing of range types.
+type Booking struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      int32      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Window  *TimeRange `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	Days    *DateRange `protobuf:"bytes,3,opt,name=days,proto3" json:"days,omitempty"`
+	Seats   *IntRange  `protobuf:"bytes,4,opt,name=seats,proto3" json:"seats,omitempty"`
+	Price   *NumRange  `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Span    *Span      `protobuf:"bytes,6,opt,name=span,proto3" json:"span,omitempty"`
+	Serials *UintRange `protobuf:"bytes,7,opt,name=serials,proto3" json:"serials,omitempty"`
+}
+
+func (x *Booking) Reset() {
+	*x = Booking{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Booking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Booking) ProtoMessage() {}
+
+func (x *Booking) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Booking.ProtoReflect.Descriptor instead.
+func (*Booking) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Booking) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Booking) GetWindow() *TimeRange {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *Booking) GetDays() *DateRange {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *Booking) GetSeats() *IntRange {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+func (x *Booking) GetPrice() *NumRange {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Booking) GetSpan() *Span {
+	if x != nil {
+		return x.Span
+	}
+	return nil
+}
+
+func (x *Booking) GetSerials() *UintRange {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+// TimeRange maps to tstzrange.
+type TimeRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lower          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=upper,proto3" json:"upper,omitempty"`
+	LowerInclusive *bool                  `protobuf:"varint,3,opt,name=lower_inclusive,json=lowerInclusive,proto3,oneof" json:"lower_inclusive,omitempty"`
+	UpperInclusive *bool                  `protobuf:"varint,4,opt,name=upper_inclusive,json=upperInclusive,proto3,oneof" json:"upper_inclusive,omitempty"`
+	Empty          bool                   `protobuf:"varint,5,opt,name=empty,proto3" json:"empty,omitempty"`
+}
+
+func (x *TimeRange) Reset() {
+	*x = TimeRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRange) ProtoMessage() {}
+
+func (x *TimeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRange.ProtoReflect.Descriptor instead.
+func (*TimeRange) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TimeRange) GetLower() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Lower
+	}
+	return nil
+}
+
+func (x *TimeRange) GetUpper() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Upper
+	}
+	return nil
+}
+
+func (x *TimeRange) GetLowerInclusive() bool {
+	if x != nil && x.LowerInclusive != nil {
+		return *x.LowerInclusive
+	}
+	return false
+}
+
+func (x *TimeRange) GetUpperInclusive() bool {
+	if x != nil && x.UpperInclusive != nil {
+		return *x.UpperInclusive
+	}
+	return false
+}
+
+func (x *TimeRange) GetEmpty() bool {
+	if x != nil {
+		return x.Empty
+	}
+	return false
+}
+
+// DateRange maps to daterange.
+type DateRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lower *date.Date `protobuf:"bytes,1,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper *date.Date `protobuf:"bytes,2,opt,name=upper,proto3" json:"upper,omitempty"`
+}
+
+func (x *DateRange) Reset() {
+	*x = DateRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DateRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DateRange) ProtoMessage() {}
+
+func (x *DateRange) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DateRange.ProtoReflect.Descriptor instead.
+func (*DateRange) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DateRange) GetLower() *date.Date {
+	if x != nil {
+		return x.Lower
+	}
+	return nil
+}
+
+func (x *DateRange) GetUpper() *date.Date {
+	if x != nil {
+		return x.Upper
+	}
+	return nil
+}
+
+// IntRange maps to int8range.
+type IntRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lower *int64 `protobuf:"varint,1,opt,name=lower,proto3,oneof" json:"lower,omitempty"`
+	Upper *int64 `protobuf:"varint,2,opt,name=upper,proto3,oneof" json:"upper,omitempty"`
+	Empty bool   `protobuf:"varint,3,opt,name=empty,proto3" json:"empty,omitempty"`
+}
+
+func (x *IntRange) Reset() {
+	*x = IntRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntRange) ProtoMessage() {}
+
+func (x *IntRange) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntRange.ProtoReflect.Descriptor instead.
+func (*IntRange) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *IntRange) GetLower() int64 {
+	if x != nil && x.Lower != nil {
+		return *x.Lower
+	}
+	return 0
+}
+
+func (x *IntRange) GetUpper() int64 {
+	if x != nil && x.Upper != nil {
+		return *x.Upper
+	}
+	return 0
+}
+
+func (x *IntRange) GetEmpty() bool {
+	if x != nil {
+		return x.Empty
+	}
+	return false
+}
+
+// NumRange maps to numrange.
+type NumRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lower          *string `protobuf:"bytes,1,opt,name=lower,proto3,oneof" json:"lower,omitempty"`
+	Upper          *string `protobuf:"bytes,2,opt,name=upper,proto3,oneof" json:"upper,omitempty"`
+	LowerInclusive bool    `protobuf:"varint,3,opt,name=lower_inclusive,json=lowerInclusive,proto3" json:"lower_inclusive,omitempty"`
+	UpperInclusive bool    `protobuf:"varint,4,opt,name=upper_inclusive,json=upperInclusive,proto3" json:"upper_inclusive,omitempty"`
+}
+
+func (x *NumRange) Reset() {
+	*x = NumRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NumRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NumRange) ProtoMessage() {}
+
+func (x *NumRange) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NumRange.ProtoReflect.Descriptor instead.
+func (*NumRange) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NumRange) GetLower() string {
+	if x != nil && x.Lower != nil {
+		return *x.Lower
+	}
+	return ""
+}
+
+func (x *NumRange) GetUpper() string {
+	if x != nil && x.Upper != nil {
+		return *x.Upper
+	}
+	return ""
+}
+
+func (x *NumRange) GetLowerInclusive() bool {
+	if x != nil {
+		return x.LowerInclusive
+	}
+	return false
+}
+
+func (x *NumRange) GetUpperInclusive() bool {
+	if x != nil {
+		return x.UpperInclusive
+	}
+	return false
+}
+
+// Schedule is used for unit testing of composite attributes of range types.
+type Schedule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Slot  *Slot   `protobuf:"bytes,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	Slots []*Slot `protobuf:"bytes,2,rep,name=slots,proto3" json:"slots,omitempty"`
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Schedule) GetSlot() *Slot {
+	if x != nil {
+		return x.Slot
+	}
+	return nil
+}
+
+func (x *Schedule) GetSlots() []*Slot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+// Slot is a composite type with a range attribute.
+type Slot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int32     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Seats *IntRange `protobuf:"bytes,2,opt,name=seats,proto3" json:"seats,omitempty"`
+}
+
+func (x *Slot) Reset() {
+	*x = Slot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Slot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Slot) ProtoMessage() {}
+
+func (x *Slot) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Slot.ProtoReflect.Descriptor instead.
+func (*Slot) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Slot) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Slot) GetSeats() *IntRange {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+// UintRange maps to numrange.
+type UintRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lower *uint64 `protobuf:"varint,1,opt,name=lower,proto3,oneof" json:"lower,omitempty"`
+	Upper *uint64 `protobuf:"varint,2,opt,name=upper,proto3,oneof" json:"upper,omitempty"`
+}
+
+func (x *UintRange) Reset() {
+	*x = UintRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UintRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UintRange) ProtoMessage() {}
+
+func (x *UintRange) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UintRange.ProtoReflect.Descriptor instead.
+func (*UintRange) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UintRange) GetLower() uint64 {
+	if x != nil && x.Lower != nil {
+		return *x.Lower
+	}
+	return 0
+}
+
+func (x *UintRange) GetUpper() uint64 {
+	if x != nil && x.Upper != nil {
+		return *x.Upper
+	}
+	return 0
+}
+
+// Span is a range type without the (pbpgx.range) option,
+// used for unit testing of RegisterRange.
+type Span struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lower *int32 `protobuf:"varint,1,opt,name=lower,proto3,oneof" json:"lower,omitempty"`
+	Upper *int32 `protobuf:"varint,2,opt,name=upper,proto3,oneof" json:"upper,omitempty"`
+}
+
+func (x *Span) Reset() {
+	*x = Span{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_support_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Span) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Span) ProtoMessage() {}
+
+func (x *Span) ProtoReflect() protoreflect.Message {
+	mi := &file_support_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Span.ProtoReflect.Descriptor instead.
+func (*Span) Descriptor() ([]byte, []int) {
+	return file_support_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Span) GetLower() int32 {
+	if x != nil && x.Lower != nil {
+		return *x.Lower
+	}
+	return 0
+}
+
+func (x *Span) GetUpper() int32 {
+	if x != nil && x.Upper != nil {
+		return *x.Upper
+	}
+	return 0
+}
+
+var File_support_proto protoreflect.FileDescriptor
+
+var file_support_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x07, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f,
+	0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70,
+	0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x64,
+	0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x6c, 0x61, 0x74, 0x6c, 0x6e, 0x67,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74,
+	0x79, 0x70, 0x65, 0x2f, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x66, 0x64, 0x61, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x70, 0x62,
+	0x70, 0x67, 0x78, 0x70, 0x62, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xf4, 0x10, 0x0a, 0x09, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65,
+	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x62, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x62,
+	0x6c, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x33, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x69, 0x33, 0x32, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x36, 0x34, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x69, 0x36, 0x34, 0x12, 0x0c, 0x0a, 0x01, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x01, 0x66, 0x12, 0x0c, 0x0a, 0x01, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01,
+	0x64, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x73, 0x12,
+	0x0e, 0x0a, 0x02, 0x62, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x62, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x33, 0x32, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x75, 0x33,
+	0x32, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x36, 0x34, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03,
+	0x75, 0x36, 0x34, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x74, 0x73, 0x12,
+	0x11, 0x0a, 0x04, 0x72, 0x5f, 0x62, 0x6c, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x08, 0x52, 0x03, 0x72,
+	0x42, 0x6c, 0x12, 0x13, 0x0a, 0x05, 0x72, 0x5f, 0x69, 0x33, 0x32, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x04, 0x72, 0x49, 0x33, 0x32, 0x12, 0x13, 0x0a, 0x05, 0x72, 0x5f, 0x69, 0x36, 0x34,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x72, 0x49, 0x36, 0x34, 0x12, 0x0f, 0x0a, 0x03,
+	0x72, 0x5f, 0x66, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x02, 0x52, 0x02, 0x72, 0x46, 0x12, 0x0f, 0x0a,
+	0x03, 0x72, 0x5f, 0x64, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x01, 0x52, 0x02, 0x72, 0x44, 0x12, 0x0f,
+	0x0a, 0x03, 0x72, 0x5f, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x72, 0x53, 0x12,
+	0x13, 0x0a, 0x05, 0x72, 0x5f, 0x75, 0x33, 0x32, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x04,
+	0x72, 0x55, 0x33, 0x32, 0x12, 0x11, 0x0a, 0x04, 0x72, 0x5f, 0x62, 0x74, 0x18, 0x12, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x03, 0x72, 0x42, 0x74, 0x12, 0x13, 0x0a, 0x05, 0x72, 0x5f, 0x75, 0x36, 0x34,
+	0x18, 0x13, 0x20, 0x03, 0x28, 0x04, 0x52, 0x04, 0x72, 0x55, 0x36, 0x34, 0x12, 0x2d, 0x0a, 0x04,
+	0x72, 0x5f, 0x74, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x72, 0x54, 0x73, 0x12, 0x10, 0x0a, 0x02, 0x6f,
+	0x62, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x62, 0x12, 0x10, 0x0a,
+	0x02, 0x6f, 0x69, 0x18, 0x16, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x69, 0x12,
+	0x26, 0x0a, 0x02, 0x65, 0x6e, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x73, 0x75,
+	0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x73, 0x52, 0x02, 0x65, 0x6e, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x5f, 0x65, 0x6e, 0x18,
+	0x18, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x03, 0x72,
+	0x45, 0x6e, 0x12, 0x2a, 0x0a, 0x02, 0x6d, 0x70, 0x18, 0x19, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x2e, 0x4d, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x02, 0x6d, 0x70, 0x12, 0x31,
+	0x0a, 0x05, 0x74, 0x73, 0x5f, 0x6d, 0x70, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65,
+	0x64, 0x2e, 0x54, 0x73, 0x4d, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x73, 0x4d,
+	0x70, 0x12, 0x2e, 0x0a, 0x04, 0x73, 0x5f, 0x6d, 0x70, 0x18, 0x1b, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x2e, 0x53, 0x4d, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x73, 0x4d,
+	0x70, 0x12, 0x2d, 0x0a, 0x04, 0x77, 0x5f, 0x62, 0x6c, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x77, 0x42, 0x6c,
+	0x12, 0x30, 0x0a, 0x05, 0x77, 0x5f, 0x69, 0x33, 0x32, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x77, 0x49,
+	0x33, 0x32, 0x12, 0x30, 0x0a, 0x05, 0x77, 0x5f, 0x69, 0x36, 0x34, 0x18, 0x1e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04,
+	0x77, 0x49, 0x36, 0x34, 0x12, 0x2c, 0x0a, 0x03, 0x77, 0x5f, 0x66, 0x18, 0x1f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x02,
+	0x77, 0x46, 0x12, 0x2d, 0x0a, 0x03, 0x77, 0x5f, 0x64, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x02, 0x77,
+	0x44, 0x12, 0x2d, 0x0a, 0x03, 0x77, 0x5f, 0x73, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x02, 0x77, 0x53,
+	0x12, 0x2e, 0x0a, 0x04, 0x77, 0x5f, 0x62, 0x74, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x77, 0x42, 0x74,
+	0x12, 0x31, 0x0a, 0x05, 0x77, 0x5f, 0x75, 0x33, 0x32, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x77,
+	0x55, 0x33, 0x32, 0x12, 0x31, 0x0a, 0x05, 0x77, 0x5f, 0x75, 0x36, 0x34, 0x18, 0x24, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x04, 0x77, 0x55, 0x36, 0x34, 0x12, 0x29, 0x0a, 0x02, 0x64, 0x75, 0x18, 0x25, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x02, 0x64,
+	0x75, 0x12, 0x2c, 0x0a, 0x04, 0x72, 0x5f, 0x64, 0x75, 0x18, 0x26, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x72, 0x44, 0x75, 0x12,
+	0x27, 0x0a, 0x02, 0x73, 0x74, 0x18, 0x27, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x02, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18,
+	0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x76,
+	0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x02, 0x6c, 0x76, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x02, 0x6c, 0x76, 0x12, 0x2a,
+	0x0a, 0x04, 0x72, 0x5f, 0x73, 0x74, 0x18, 0x2a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x03, 0x72, 0x53, 0x74, 0x12, 0x21, 0x0a, 0x02, 0x64, 0x74,
+	0x18, 0x2b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x02, 0x64, 0x74, 0x12, 0x28, 0x0a,
+	0x03, 0x74, 0x6f, 0x64, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x44,
+	0x61, 0x79, 0x52, 0x03, 0x74, 0x6f, 0x64, 0x12, 0x23, 0x0a, 0x02, 0x6c, 0x6c, 0x18, 0x2d, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x52, 0x02, 0x6c, 0x6c, 0x12, 0x26, 0x0a, 0x03,
+	0x64, 0x65, 0x63, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52,
+	0x03, 0x64, 0x65, 0x63, 0x12, 0x24, 0x0a, 0x03, 0x6d, 0x6f, 0x6e, 0x18, 0x2f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e,
+	0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x03, 0x6d, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x5f,
+	0x64, 0x74, 0x18, 0x30, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x03, 0x72, 0x44, 0x74,
+	0x12, 0x2b, 0x0a, 0x05, 0x72, 0x5f, 0x74, 0x6f, 0x64, 0x18, 0x31, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x4f, 0x66, 0x44, 0x61, 0x79, 0x52, 0x04, 0x72, 0x54, 0x6f, 0x64, 0x12, 0x26, 0x0a,
+	0x04, 0x72, 0x5f, 0x6c, 0x6c, 0x18, 0x32, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67,
+	0x52, 0x03, 0x72, 0x4c, 0x6c, 0x12, 0x29, 0x0a, 0x05, 0x72, 0x5f, 0x64, 0x65, 0x63, 0x18, 0x33,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x04, 0x72, 0x44, 0x65, 0x63,
+	0x12, 0x27, 0x0a, 0x05, 0x72, 0x5f, 0x6d, 0x6f, 0x6e, 0x18, 0x34, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x4d, 0x6f,
+	0x6e, 0x65, 0x79, 0x52, 0x04, 0x72, 0x4d, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x05, 0x6f, 0x5f, 0x69,
+	0x33, 0x32, 0x18, 0x35, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x04, 0x6f, 0x49, 0x33, 0x32,
+	0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x03, 0x6f, 0x5f, 0x73, 0x18, 0x36, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x02, 0x52, 0x02, 0x6f, 0x53, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x04, 0x6f, 0x5f, 0x65,
+	0x6e, 0x18, 0x37, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72,
+	0x74, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x48,
+	0x03, 0x52, 0x03, 0x6f, 0x45, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x32, 0x0a, 0x04, 0x6f, 0x5f, 0x74,
+	0x73, 0x18, 0x38, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x22, 0x4f, 0x0a, 0x0b,
-	0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x07, 0x63,
-	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x73,
-	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x6c,
-	0x75, 0x6d, 0x6e, 0x73, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x2a, 0x39, 0x0a,
-	0x0d, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x06,
-	0x0a, 0x02, 0x69, 0x64, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x10,
-	0x01, 0x12, 0x08, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x63,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x03, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x68, 0x6c, 0x65, 0x6d, 0x6d, 0x65, 0x72,
-	0x2f, 0x70, 0x62, 0x70, 0x67, 0x78, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
-	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x6d, 0x70, 0x48, 0x04, 0x52, 0x03, 0x6f, 0x54, 0x73, 0x88, 0x01, 0x01, 0x1a, 0x35, 0x0a,
+	0x07, 0x4d, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x53, 0x0a, 0x09, 0x54, 0x73, 0x4d, 0x70, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x36, 0x0a, 0x08, 0x53, 0x4d, 0x70,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x42, 0x03, 0x0a, 0x01, 0x6f, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6f, 0x5f, 0x69, 0x33, 0x32,
+	0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6f, 0x5f, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6f, 0x5f, 0x65,
+	0x6e, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6f, 0x5f, 0x74, 0x73, 0x22, 0x68, 0x0a, 0x0b, 0x55, 0x6e,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x03, 0x73, 0x75, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52, 0x03, 0x73, 0x75, 0x70, 0x12,
+	0x33, 0x0a, 0x07, 0x72, 0x5f, 0x77, 0x5f, 0x69, 0x36, 0x34, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x72,
+	0x57, 0x49, 0x36, 0x34, 0x22, 0x1e, 0x0a, 0x06, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x55, 0x0a, 0x0a, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x65, 0x64, 0x12, 0x21, 0x0a, 0x03, 0x63, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x52, 0x03, 0x63, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x72, 0x5f, 0x63, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x52, 0x04, 0x72, 0x43, 0x73, 0x74, 0x22, 0x78, 0x0a, 0x06, 0x53,
+	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x22, 0x4f, 0x0a, 0x0b, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x07, 0x63,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x22, 0x72, 0x0a, 0x06, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79,
+	0x12, 0x1d, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x0d, 0x82, 0x80,
+	0x19, 0x09, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x5f, 0x69, 0x64, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x26, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x10,
+	0x82, 0x80, 0x19, 0x0c, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x3a, 0x0d, 0x82, 0x80, 0x19,
+	0x09, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x5f, 0x72, 0x77, 0x22, 0xb8, 0x01, 0x0a, 0x06, 0x50,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x2a, 0x0a, 0x08, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x08, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x2a, 0x0a, 0x08, 0x66, 0x61, 0x76, 0x6f, 0x72,
+	0x69, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x75, 0x70, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x08, 0x66, 0x61, 0x76, 0x6f, 0x72,
+	0x69, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x6e, 0x75, 0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x05,
+	0x6e, 0x75, 0x6c, 0x6c, 0x73, 0x22, 0x5b, 0x0a, 0x05, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x62, 0x6f, 0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x62, 0x6f,
+	0x72, 0x6e, 0x22, 0x87, 0x01, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x68,
+	0x69, 0x6c, 0x64, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x12, 0x30, 0x0a, 0x05, 0x6e, 0x75,
+	0x6c, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x05, 0x6e, 0x75, 0x6c, 0x6c, 0x73, 0x22, 0x74, 0x0a, 0x04,
+	0x47, 0x72, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x6f, 0x77,
+	0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x26, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x22, 0x1b, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x65, 0x6c,
+	0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x22,
+	0x29, 0x0a, 0x05, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x12, 0x20, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x22, 0x90, 0x02, 0x0a, 0x07, 0x42,
+	0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x12, 0x26, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x65,
+	0x61, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x75, 0x70, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x05, 0x73, 0x65,
+	0x61, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4e, 0x75, 0x6d,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x04,
+	0x73, 0x70, 0x61, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x75, 0x70,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x12,
+	0x2c, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x55, 0x69, 0x6e, 0x74, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x22, 0x8f, 0x02,
+	0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x6c,
+	0x6f, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x30, 0x0a,
+	0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x12,
+	0x2c, 0x0a, 0x0f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69,
+	0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0e, 0x6c, 0x6f, 0x77, 0x65,
+	0x72, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a,
+	0x0f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x0e, 0x75, 0x70, 0x70, 0x65, 0x72, 0x49,
+	0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x6d, 0x70, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x6d, 0x70, 0x74,
+	0x79, 0x3a, 0x04, 0x88, 0x80, 0x19, 0x01, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6c, 0x6f, 0x77, 0x65,
+	0x72, 0x5f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f,
+	0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x22,
+	0x63, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x05,
+	0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x05,
+	0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x3a, 0x04,
+	0x88, 0x80, 0x19, 0x01, 0x22, 0x70, 0x0a, 0x08, 0x49, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x19, 0x0a, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48,
+	0x00, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x75,
+	0x70, 0x70, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x05, 0x75, 0x70,
+	0x70, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x3a, 0x04, 0x88, 0x80,
+	0x19, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x42, 0x08, 0x0a, 0x06,
+	0x5f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x22, 0xac, 0x01, 0x0a, 0x08, 0x4e, 0x75, 0x6d, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x19,
+	0x0a, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52,
+	0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x6f, 0x77,
+	0x65, 0x72, 0x5f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69,
+	0x76, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x75, 0x70, 0x70,
+	0x65, 0x72, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x3a, 0x04, 0x88, 0x80, 0x19,
+	0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x5f,
+	0x75, 0x70, 0x70, 0x65, 0x72, 0x22, 0x52, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x12, 0x21, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x04,
+	0x73, 0x6c, 0x6f, 0x74, 0x12, 0x23, 0x0a, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x6c,
+	0x6f, 0x74, 0x52, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x22, 0x3f, 0x0a, 0x04, 0x53, 0x6c, 0x6f,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x22, 0x5b, 0x0a, 0x09, 0x55, 0x69,
+	0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x88,
+	0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x48, 0x01, 0x52, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x88, 0x01, 0x01, 0x3a, 0x04, 0x88,
+	0x80, 0x19, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x42, 0x08, 0x0a,
+	0x06, 0x5f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x22, 0x50, 0x0a, 0x04, 0x53, 0x70, 0x61, 0x6e, 0x12,
+	0x19, 0x0a, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00,
+	0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x75, 0x70,
+	0x70, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x05, 0x75, 0x70, 0x70,
+	0x65, 0x72, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x42,
+	0x08, 0x0a, 0x06, 0x5f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x2a, 0x39, 0x0a, 0x0d, 0x53, 0x69, 0x6d,
+	0x70, 0x6c, 0x65, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x06, 0x0a, 0x02, 0x69, 0x64,
+	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x10, 0x01, 0x12, 0x08, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x10, 0x03, 0x2a, 0x4b, 0x0a, 0x0d, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x43, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x0d, 0x0a, 0x09, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x5f,
+	0x69, 0x64, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x5f, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x0b, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79,
+	0x5f, 0x64, 0x61, 0x74, 0x61, 0x10, 0x02, 0x1a, 0x08, 0x82, 0x80, 0x19, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6d, 0x75, 0x68, 0x6c, 0x65, 0x6d, 0x6d, 0x65, 0x72, 0x2f, 0x70, 0x62, 0x70, 0x67, 0x78, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -632,34 +2328,126 @@ func file_support_proto_rawDescGZIP() []byte {
 	return file_support_proto_rawDescData
 }
 
-var file_support_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_support_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_support_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_support_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_support_proto_goTypes = []interface{}{
-	(SimpleColumns)(0),            // 0: support.SimpleColumns
-	(*Supported)(nil),             // 1: support.Supported
-	(*Unsupported)(nil),           // 2: support.Unsupported
-	(*Simple)(nil),                // 3: support.Simple
-	(*SimpleQuery)(nil),           // 4: support.SimpleQuery
-	nil,                           // 5: support.Unsupported.MpEntry
-	nil,                           // 6: support.Unsupported.TsMpEntry
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(SimpleColumns)(0),             // 0: support.SimpleColumns
+	(LegacyColumns)(0),             // 1: support.LegacyColumns
+	(*Supported)(nil),              // 2: support.Supported
+	(*Unsupported)(nil),            // 3: support.Unsupported
+	(*Custom)(nil),                 // 4: support.Custom
+	(*Registered)(nil),             // 5: support.Registered
+	(*Simple)(nil),                 // 6: support.Simple
+	(*SimpleQuery)(nil),            // 7: support.SimpleQuery
+	(*Legacy)(nil),                 // 8: support.Legacy
+	(*Parent)(nil),                 // 9: support.Parent
+	(*Child)(nil),                  // 10: support.Child
+	(*Tracked)(nil),                // 11: support.Tracked
+	(*Grid)(nil),                   // 12: support.Grid
+	(*Row)(nil),                    // 13: support.Row
+	(*Plane)(nil),                  // 14: support.Plane
+	(*Booking)(nil),                // 15: support.Booking
+	(*TimeRange)(nil),              // 16: support.TimeRange
+	(*DateRange)(nil),              // 17: support.DateRange
+	(*IntRange)(nil),               // 18: support.IntRange
+	(*NumRange)(nil),               // 19: support.NumRange
+	(*Schedule)(nil),               // 20: support.Schedule
+	(*Slot)(nil),                   // 21: support.Slot
+	(*UintRange)(nil),              // 22: support.UintRange
+	(*Span)(nil),                   // 23: support.Span
+	nil,                            // 24: support.Supported.MpEntry
+	nil,                            // 25: support.Supported.TsMpEntry
+	nil,                            // 26: support.Supported.SMpEntry
+	(*timestamppb.Timestamp)(nil),  // 27: google.protobuf.Timestamp
+	(*wrapperspb.BoolValue)(nil),   // 28: google.protobuf.BoolValue
+	(*wrapperspb.Int32Value)(nil),  // 29: google.protobuf.Int32Value
+	(*wrapperspb.Int64Value)(nil),  // 30: google.protobuf.Int64Value
+	(*wrapperspb.FloatValue)(nil),  // 31: google.protobuf.FloatValue
+	(*wrapperspb.DoubleValue)(nil), // 32: google.protobuf.DoubleValue
+	(*wrapperspb.StringValue)(nil), // 33: google.protobuf.StringValue
+	(*wrapperspb.BytesValue)(nil),  // 34: google.protobuf.BytesValue
+	(*wrapperspb.UInt32Value)(nil), // 35: google.protobuf.UInt32Value
+	(*wrapperspb.UInt64Value)(nil), // 36: google.protobuf.UInt64Value
+	(*durationpb.Duration)(nil),    // 37: google.protobuf.Duration
+	(*structpb.Struct)(nil),        // 38: google.protobuf.Struct
+	(*structpb.Value)(nil),         // 39: google.protobuf.Value
+	(*structpb.ListValue)(nil),     // 40: google.protobuf.ListValue
+	(*date.Date)(nil),              // 41: google.type.Date
+	(*timeofday.TimeOfDay)(nil),    // 42: google.type.TimeOfDay
+	(*latlng.LatLng)(nil),          // 43: google.type.LatLng
+	(*decimal.Decimal)(nil),        // 44: google.type.Decimal
+	(*money.Money)(nil),            // 45: google.type.Money
+	(*fieldmaskpb.FieldMask)(nil),  // 46: google.protobuf.FieldMask
 }
 var file_support_proto_depIdxs = []int32{
-	7,  // 0: support.Supported.ts:type_name -> google.protobuf.Timestamp
-	7,  // 1: support.Supported.r_ts:type_name -> google.protobuf.Timestamp
-	1,  // 2: support.Unsupported.sup:type_name -> support.Supported
-	5,  // 3: support.Unsupported.mp:type_name -> support.Unsupported.MpEntry
-	6,  // 4: support.Unsupported.ts_mp:type_name -> support.Unsupported.TsMpEntry
-	0,  // 5: support.Unsupported.en:type_name -> support.SimpleColumns
-	0,  // 6: support.Unsupported.r_en:type_name -> support.SimpleColumns
-	7,  // 7: support.Simple.created:type_name -> google.protobuf.Timestamp
-	0,  // 8: support.SimpleQuery.columns:type_name -> support.SimpleColumns
-	7,  // 9: support.Unsupported.TsMpEntry.value:type_name -> google.protobuf.Timestamp
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	27, // 0: support.Supported.ts:type_name -> google.protobuf.Timestamp
+	27, // 1: support.Supported.r_ts:type_name -> google.protobuf.Timestamp
+	0,  // 2: support.Supported.en:type_name -> support.SimpleColumns
+	0,  // 3: support.Supported.r_en:type_name -> support.SimpleColumns
+	24, // 4: support.Supported.mp:type_name -> support.Supported.MpEntry
+	25, // 5: support.Supported.ts_mp:type_name -> support.Supported.TsMpEntry
+	26, // 6: support.Supported.s_mp:type_name -> support.Supported.SMpEntry
+	28, // 7: support.Supported.w_bl:type_name -> google.protobuf.BoolValue
+	29, // 8: support.Supported.w_i32:type_name -> google.protobuf.Int32Value
+	30, // 9: support.Supported.w_i64:type_name -> google.protobuf.Int64Value
+	31, // 10: support.Supported.w_f:type_name -> google.protobuf.FloatValue
+	32, // 11: support.Supported.w_d:type_name -> google.protobuf.DoubleValue
+	33, // 12: support.Supported.w_s:type_name -> google.protobuf.StringValue
+	34, // 13: support.Supported.w_bt:type_name -> google.protobuf.BytesValue
+	35, // 14: support.Supported.w_u32:type_name -> google.protobuf.UInt32Value
+	36, // 15: support.Supported.w_u64:type_name -> google.protobuf.UInt64Value
+	37, // 16: support.Supported.du:type_name -> google.protobuf.Duration
+	37, // 17: support.Supported.r_du:type_name -> google.protobuf.Duration
+	38, // 18: support.Supported.st:type_name -> google.protobuf.Struct
+	39, // 19: support.Supported.val:type_name -> google.protobuf.Value
+	40, // 20: support.Supported.lv:type_name -> google.protobuf.ListValue
+	38, // 21: support.Supported.r_st:type_name -> google.protobuf.Struct
+	41, // 22: support.Supported.dt:type_name -> google.type.Date
+	42, // 23: support.Supported.tod:type_name -> google.type.TimeOfDay
+	43, // 24: support.Supported.ll:type_name -> google.type.LatLng
+	44, // 25: support.Supported.dec:type_name -> google.type.Decimal
+	45, // 26: support.Supported.mon:type_name -> google.type.Money
+	41, // 27: support.Supported.r_dt:type_name -> google.type.Date
+	42, // 28: support.Supported.r_tod:type_name -> google.type.TimeOfDay
+	43, // 29: support.Supported.r_ll:type_name -> google.type.LatLng
+	44, // 30: support.Supported.r_dec:type_name -> google.type.Decimal
+	45, // 31: support.Supported.r_mon:type_name -> google.type.Money
+	0,  // 32: support.Supported.o_en:type_name -> support.SimpleColumns
+	27, // 33: support.Supported.o_ts:type_name -> google.protobuf.Timestamp
+	2,  // 34: support.Unsupported.sup:type_name -> support.Supported
+	30, // 35: support.Unsupported.r_w_i64:type_name -> google.protobuf.Int64Value
+	4,  // 36: support.Registered.cst:type_name -> support.Custom
+	4,  // 37: support.Registered.r_cst:type_name -> support.Custom
+	27, // 38: support.Simple.created:type_name -> google.protobuf.Timestamp
+	0,  // 39: support.SimpleQuery.columns:type_name -> support.SimpleColumns
+	10, // 40: support.Parent.children:type_name -> support.Child
+	10, // 41: support.Parent.favorite:type_name -> support.Child
+	46, // 42: support.Parent.nulls:type_name -> google.protobuf.FieldMask
+	27, // 43: support.Child.born:type_name -> google.protobuf.Timestamp
+	10, // 44: support.Tracked.child:type_name -> support.Child
+	46, // 45: support.Tracked.nulls:type_name -> google.protobuf.FieldMask
+	13, // 46: support.Grid.rows:type_name -> support.Row
+	14, // 47: support.Grid.planes:type_name -> support.Plane
+	13, // 48: support.Plane.rows:type_name -> support.Row
+	16, // 49: support.Booking.window:type_name -> support.TimeRange
+	17, // 50: support.Booking.days:type_name -> support.DateRange
+	18, // 51: support.Booking.seats:type_name -> support.IntRange
+	19, // 52: support.Booking.price:type_name -> support.NumRange
+	23, // 53: support.Booking.span:type_name -> support.Span
+	22, // 54: support.Booking.serials:type_name -> support.UintRange
+	27, // 55: support.TimeRange.lower:type_name -> google.protobuf.Timestamp
+	27, // 56: support.TimeRange.upper:type_name -> google.protobuf.Timestamp
+	41, // 57: support.DateRange.lower:type_name -> google.type.Date
+	41, // 58: support.DateRange.upper:type_name -> google.type.Date
+	21, // 59: support.Schedule.slot:type_name -> support.Slot
+	21, // 60: support.Schedule.slots:type_name -> support.Slot
+	18, // 61: support.Slot.seats:type_name -> support.IntRange
+	27, // 62: support.Supported.TsMpEntry.value:type_name -> google.protobuf.Timestamp
+	63, // [63:63] is the sub-list for method output_type
+	63, // [63:63] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
 }
 
 func init() { file_support_proto_init() }
@@ -693,7 +2481,7 @@ func file_support_proto_init() {
 			}
 		}
 		file_support_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Simple); i {
+			switch v := v.(*Custom); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -705,6 +2493,30 @@ func file_support_proto_init() {
 			}
 		}
 		file_support_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Registered); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Simple); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SimpleQuery); i {
 			case 0:
 				return &v.state
@@ -716,18 +2528,215 @@ func file_support_proto_init() {
 				return nil
 			}
 		}
+		file_support_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Legacy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Parent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Child); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tracked); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Grid); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Row); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Plane); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Booking); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DateRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NumRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Schedule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Slot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UintRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_support_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Span); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_support_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*Supported_Ob)(nil),
 		(*Supported_Oi)(nil),
 	}
+	file_support_proto_msgTypes[14].OneofWrappers = []interface{}{}
+	file_support_proto_msgTypes[16].OneofWrappers = []interface{}{}
+	file_support_proto_msgTypes[17].OneofWrappers = []interface{}{}
+	file_support_proto_msgTypes[20].OneofWrappers = []interface{}{}
+	file_support_proto_msgTypes[21].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_support_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
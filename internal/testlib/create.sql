@@ -46,4 +46,45 @@ create table unsupported (
     u64 bigint[],
     sup bytea,
     ts timetz
-);
\ No newline at end of file
+);
+
+create extension if not exists hstore;
+
+create type simple_column as enum ('id', 'title', 'data', 'created');
+
+create type money_amount as (currency_code text, amount numeric);
+
+create table supported (
+    s uuid null,
+    bt uuid null,
+    r_s macaddr[] null,
+    en simple_column null,
+    r_en simple_column[] null,
+    s_mp hstore null,
+    du interval null,
+    r_du interval[] null,
+    mon money_amount null,
+    r_mon money_amount[] null,
+    o_s inet null
+);
+
+create table parent_blobs (
+    id integer primary key not null,
+    title text null,
+    children bytea[] null,
+    favorite bytea null
+);
+
+create type slot as (id integer, seats int8range);
+
+create table schedules (
+    slot slot null,
+    slots slot[] null
+);
+
+create table bookings (
+    id integer primary key not null,
+    "window" tstzrange null,
+    days daterange null,
+    seats int8range null
+);
@@ -2,3 +2,10 @@ drop table if exists simple_rw;
 drop table if exists simple_ro;
 drop table if exists products;
 drop table if exists unsupported;
+drop table if exists supported;
+drop table if exists parent_blobs;
+drop table if exists schedules;
+drop table if exists bookings;
+drop type if exists simple_column;
+drop type if exists money_amount;
+drop type if exists slot;
@@ -0,0 +1,395 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NullElementPolicy is the policy for NULL elements in arrays,
+// scanned into repeated fields.
+type NullElementPolicy int
+
+const (
+	// NullElementError results in an error for arrays with NULL elements.
+	NullElementError NullElementPolicy = iota
+
+	// NullElementSkip leaves NULL elements out of the repeated field.
+	NullElementSkip
+
+	// NullElementZero appends the zero value of the element type for NULL elements,
+	// such as 0, an empty string or an empty message.
+	NullElementZero
+)
+
+var arrayDimensionsType = reflect.TypeOf([]pgtype.ArrayDimension(nil))
+
+// arrayFields returns the Elements and Dimensions fields of pgtype array types,
+// such as pgtype.Int4Array. False is returned for other types.
+func arrayFields(v pgtype.Value) (elems, dims reflect.Value, ok bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return elems, dims, false
+	}
+
+	elems, dims = rv.Elem().FieldByName("Elements"), rv.Elem().FieldByName("Dimensions")
+	if elems.Kind() != reflect.Slice || !dims.IsValid() || dims.Type() != arrayDimensionsType {
+		return elems, dims, false
+	}
+
+	return elems, dims, true
+}
+
+// setArrayFields sets the elements and dimensions of a pgtype array type, with status Present.
+func setArrayFields(v pgtype.Value, elems reflect.Value, dims []pgtype.ArrayDimension) error {
+	velems, vdims, ok := arrayFields(v)
+	if !ok {
+		return fmt.Errorf("array type %T not supported", v)
+	}
+
+	velems.Set(elems)
+	vdims.Set(reflect.ValueOf(dims))
+	reflect.ValueOf(v).Elem().FieldByName("Status").Set(reflect.ValueOf(pgtype.Present))
+
+	return nil
+}
+
+// arrayElement returns element i of a pgtype array type, as returned by arrayFields.
+func arrayElement(elems reflect.Value, i int) pgtype.Value {
+	return elems.Index(i).Addr().Interface().(pgtype.Value)
+}
+
+// nullElements returns the indices of the NULL elements in a single dimensional array.
+func nullElements(v pgtype.Value) (indices []int) {
+	if elems, dims, ok := arrayFields(v); ok {
+		if dims.Len() > 1 {
+			return nil
+		}
+
+		for i := 0; i < elems.Len(); i++ {
+			if arrayElement(elems, i).Get() == nil {
+				indices = append(indices, i)
+			}
+		}
+
+		return indices
+	}
+
+	if at, ok := v.(*pgtype.ArrayType); ok {
+		elems, _ := at.Get().([]interface{})
+		for i, elem := range elems {
+			if elem == nil {
+				indices = append(indices, i)
+			}
+		}
+	}
+
+	return indices
+}
+
+// removeNullElements removes the NULL elements from a single dimensional array.
+func removeNullElements(v pgtype.Value) error {
+	if elems, _, ok := arrayFields(v); ok {
+		present := reflect.MakeSlice(elems.Type(), 0, elems.Len())
+		for i := 0; i < elems.Len(); i++ {
+			if arrayElement(elems, i).Get() != nil {
+				present = reflect.Append(present, elems.Index(i))
+			}
+		}
+
+		return setArrayFields(v, present, []pgtype.ArrayDimension{{Length: int32(present.Len()), LowerBound: 1}})
+	}
+
+	elems, _ := v.Get().([]interface{})
+	present := make([]interface{}, 0, len(elems))
+
+	for _, elem := range elems {
+		switch e := elem.(type) {
+		case nil:
+		case messageElement:
+			m, err := e.toMessage()
+			if err != nil {
+				return err
+			}
+			present = append(present, m)
+		default:
+			present = append(present, e)
+		}
+	}
+
+	return v.Set(present)
+}
+
+// isArrayValue reports whether v holds a pgtype array type,
+// to which a NullElementPolicy can be applied.
+func isArrayValue(v Value) bool {
+	if _, ok := v.PGValue().(*pgtype.ArrayType); ok {
+		return true
+	}
+
+	_, _, ok := arrayFields(v.PGValue())
+	return ok
+}
+
+// nullElementsValue applies a NullElementPolicy to a Value of a repeated field.
+type nullElementsValue struct {
+	Value
+	fd     pr.FieldDescriptor
+	policy NullElementPolicy
+}
+
+func (v *nullElementsValue) SetTo(msg pr.Message) error {
+	indices := nullElements(v.PGValue())
+	if len(indices) == 0 {
+		return v.Value.SetTo(msg)
+	}
+
+	switch v.policy {
+	case NullElementSkip, NullElementZero:
+	default:
+		return fmt.Errorf("value: field %s: NULL array element %d", v.fd.FullName(), indices[0])
+	}
+
+	if err := removeNullElements(v.PGValue()); err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+	if err := v.Value.SetTo(msg); err != nil {
+		return err
+	}
+
+	if v.policy == NullElementZero {
+		insertZeroElements(msg, v.fd, indices)
+	}
+
+	return nil
+}
+
+// insertZeroElements inserts zero values in the list of fd, at the indices.
+// The indices must be in ascending order.
+func insertZeroElements(msg pr.Message, fd pr.FieldDescriptor, indices []int) {
+	pl := msg.Get(fd).List()
+	zl := msg.NewField(fd).List()
+
+	for i, j := 0, 0; j < pl.Len() || len(indices) > 0; i++ {
+		if len(indices) > 0 && indices[0] == i {
+			zl.Append(zl.NewElement())
+			indices = indices[1:]
+			continue
+		}
+
+		zl.Append(pl.Get(j))
+		j++
+	}
+
+	msg.Set(fd, pr.ValueOfList(zl))
+}
+
+// isArrayRow reports whether messages of md are rows of a multi-dimensional array.
+// Such messages have a single repeated field, which holds the scalar or enum elements of the row,
+// or the rows of the next dimension.
+func isArrayRow(md pr.MessageDescriptor) bool {
+	if md.Fields().Len() != 1 {
+		return false
+	}
+
+	fd := md.Fields().Get(0)
+	switch {
+	case !fd.IsList():
+		return false
+	case fd.Message() == nil:
+		return true
+	default:
+		return isArrayRow(fd.Message())
+	}
+}
+
+// isArrayOID reports whether oid is an array type known to pgtype.
+func isArrayOID(oid uint32) bool {
+	dt, ok := connInfo.DataTypeForOID(oid)
+	return ok && len(dt.Name) > 1 && dt.Name[0] == '_'
+}
+
+// arrayValue scans and writes a repeated message field from and to a multi-dimensional array column.
+// The messages are the rows of the first dimension, see isArrayRow.
+// The embedded Value holds the complete array, for the innermost repeated field.
+type arrayValue struct {
+	Value
+	o     *Options
+	fd    pr.FieldDescriptor
+	leaf  pr.FieldDescriptor
+	depth int // Number of dimensions.
+	oid   uint32
+}
+
+// newArrayValue returns an arrayValue for the repeated message field.
+func (o *Options) newArrayValue(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) (Value, error) {
+	if !fd.IsList() || !isArrayRow(fd.Message()) {
+		return nil, fmt.Errorf("value: field %s is not a repeated field of array rows", fd.FullName())
+	}
+
+	v := &arrayValue{o: o, fd: fd, leaf: fd, depth: 1, oid: oid}
+	for ; v.leaf.Message() != nil; v.depth++ {
+		v.leaf = v.leaf.Message().Fields().Get(0)
+	}
+
+	lv, err := v.newLeaf(status)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, ok := arrayFields(lv.PGValue()); !ok {
+		return nil, fmt.Errorf("value: field %s: array type %T not supported", fd.FullName(), lv.PGValue())
+	}
+
+	v.Value = lv
+	return v, nil
+}
+
+// newLeaf returns a Value for the innermost repeated field.
+func (v *arrayValue) newLeaf(status pgtype.Status) (Value, error) {
+	lv, err := v.o.New(v.leaf, status, "", v.oid)
+	if err != nil {
+		return nil, fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+
+	return lv, nil
+}
+
+func (v *arrayValue) SetTo(msg pr.Message) error {
+	if v.Get() == nil {
+		return nil
+	}
+
+	elems, dims, _ := arrayFields(v.PGValue())
+	ds := dims.Interface().([]pgtype.ArrayDimension)
+	if len(ds) == 0 {
+		return nil
+	}
+	if len(ds) != v.depth {
+		return fmt.Errorf("value: field %s: array of %d dimensions, want %d", v.fd.FullName(), len(ds), v.depth)
+	}
+
+	return v.setRows(msg, v.fd, elems, ds)
+}
+
+// setRows sets the rows of fd in msg, from the elements of an array with dims.
+// A dimension of length 0, which can be sent in binary format, results in no rows.
+func (v *arrayValue) setRows(msg pr.Message, fd pr.FieldDescriptor, elems reflect.Value, dims []pgtype.ArrayDimension) error {
+	n := int(dims[0].Length)
+	if n <= 0 {
+		return nil
+	}
+
+	pl := msg.NewField(fd).List()
+	size := elems.Len() / n
+
+	for i := 0; i < n; i++ {
+		row := pl.NewElement().Message()
+		child := row.Descriptor().Fields().Get(0)
+		sub := elems.Slice(i*size, (i+1)*size)
+
+		if len(dims) > 2 {
+			if err := v.setRows(row, child, sub, dims[1:]); err != nil {
+				return err
+			}
+		} else {
+			lv, err := v.newLeaf(pgtype.Undefined)
+			if err != nil {
+				return err
+			}
+			if err = setArrayFields(lv.PGValue(), sub, dims[1:]); err != nil {
+				return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+			}
+			if err = lv.SetTo(row); err != nil {
+				return err
+			}
+		}
+
+		pl.Append(pr.ValueOfMessage(row))
+	}
+
+	msg.Set(fd, pr.ValueOfList(pl))
+	return nil
+}
+
+func (v *arrayValue) SetFrom(msg pr.Message) error {
+	elems, dims, err := v.rows(msg.Get(v.fd).List())
+	if err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+
+	velems, _, _ := arrayFields(v.PGValue())
+	if elems.Len() == 0 {
+		// PostgreSQL has no empty multi-dimensional arrays.
+		return setArrayFields(v.PGValue(), reflect.MakeSlice(velems.Type(), 0, 0), nil)
+	}
+
+	return setArrayFields(v.PGValue(), elems, dims)
+}
+
+// rows returns the elements and dimensions of the array formed by the rows in pl.
+// All rows in a dimension must be of the same length.
+func (v *arrayValue) rows(pl pr.List) (elems reflect.Value, dims []pgtype.ArrayDimension, err error) {
+	velems, _, _ := arrayFields(v.PGValue())
+	elems = reflect.MakeSlice(velems.Type(), 0, 0)
+
+	for i := 0; i < pl.Len(); i++ {
+		row := pl.Get(i).Message()
+		child := row.Descriptor().Fields().Get(0)
+
+		var (
+			re reflect.Value
+			rd []pgtype.ArrayDimension
+		)
+
+		if child.Message() != nil {
+			if re, rd, err = v.rows(row.Get(child).List()); err != nil {
+				return elems, nil, err
+			}
+		} else {
+			lv, err := v.newLeaf(pgtype.Present)
+			if err != nil {
+				return elems, nil, err
+			}
+			if err = lv.SetFrom(row); err != nil {
+				return elems, nil, err
+			}
+
+			re, _, _ = arrayFields(lv.PGValue())
+			rd = []pgtype.ArrayDimension{{Length: int32(re.Len()), LowerBound: 1}}
+		}
+
+		if i > 0 && !reflect.DeepEqual(rd, dims[1:]) {
+			return elems, nil, fmt.Errorf("row %d differs in length from row 0", i)
+		}
+
+		elems = reflect.AppendSlice(elems, re)
+		dims = append([]pgtype.ArrayDimension{{LowerBound: 1}}, rd...)
+	}
+
+	if len(dims) > 0 {
+		dims[0].Length = int32(pl.Len())
+	}
+
+	return elems, dims, nil
+}
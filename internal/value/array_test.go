@@ -0,0 +1,268 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNullElementPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   pr.Name
+		oid     uint32
+		src     string
+		policy  NullElementPolicy
+		want    *support.Supported
+		wantErr bool
+	}{
+		{"no NULL", "r_i32", pgtype.Int4ArrayOID, "{1,2}", NullElementError, &support.Supported{RI32: []int32{1, 2}}, false},
+		{"error", "r_i32", pgtype.Int4ArrayOID, "{1,NULL}", NullElementError, nil, true},
+		{"skip", "r_i32", pgtype.Int4ArrayOID, "{NULL,1,NULL,2}", NullElementSkip, &support.Supported{RI32: []int32{1, 2}}, false},
+		{"zero", "r_i32", pgtype.Int4ArrayOID, "{NULL,1,NULL,2,NULL}", NullElementZero, &support.Supported{RI32: []int32{0, 1, 0, 2, 0}}, false},
+		{"all NULL", "r_s", pgtype.TextArrayOID, "{NULL,NULL}", NullElementZero, &support.Supported{RS: []string{"", ""}}, false},
+		{"skip all", "r_s", pgtype.TextArrayOID, "{NULL}", NullElementSkip, &support.Supported{}, false},
+		{"enum", "r_en", pgtype.Int4ArrayOID, "{NULL,1}", NullElementZero, &support.Supported{REn: []support.SimpleColumns{0, 1}}, false},
+		{"message", "r_du", 0, "{NULL,\"00:00:01\"}", NullElementZero, &support.Supported{RDu: []*durationpb.Duration{{}, {Seconds: 1}}}, false},
+		{"message skip", "r_du", 0, "{NULL,\"00:00:01\"}", NullElementSkip, &support.Supported{RDu: []*durationpb.Duration{{Seconds: 1}}}, false},
+		{"timestamp", "r_ts", pgtype.TimestamptzArrayOID, "{NULL,\"1970-01-01 00:00:01Z\"}", NullElementZero, &support.Supported{RTs: []*timestamppb.Timestamp{{}, {Seconds: 1}}}, false},
+		{"message error", "r_du", 0, "{NULL,\"00:00:01\"}", NullElementError, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := (&support.Supported{}).ProtoReflect().Descriptor().Fields().ByName(tt.field)
+
+			v, err := (&Options{NullElements: tt.policy}).New(fd, pgtype.Undefined, string(tt.field), tt.oid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = v.DecodeText(connInfo, []byte(tt.src)); err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(support.Supported)
+			err = v.SetTo(got.ProtoReflect())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Value.SetTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !proto.Equal(got, tt.want) {
+				t.Errorf("Value.SetTo() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isArrayRow(t *testing.T) {
+	tests := []struct {
+		name string
+		md   pr.MessageDescriptor
+		want bool
+	}{
+		{"row", (&support.Row{}).ProtoReflect().Descriptor(), true},
+		{"plane", (&support.Plane{}).ProtoReflect().Descriptor(), true},
+		{"grid", (&support.Grid{}).ProtoReflect().Descriptor(), false},
+		{"child", (&support.Child{}).ProtoReflect().Descriptor(), false},
+		{"duration", durationpb.File_google_protobuf_duration_proto.Messages().ByName("Duration"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isArrayRow(tt.md); got != tt.want {
+				t.Errorf("isArrayRow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func rows(cells ...[]int32) []*support.Row {
+	rows := make([]*support.Row, len(cells))
+	for i, c := range cells {
+		rows[i] = &support.Row{Cells: c}
+	}
+	return rows
+}
+
+func Test_arrayValue_SetTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   pr.Name
+		src     string
+		policy  NullElementPolicy
+		want    *support.Grid
+		wantErr bool
+	}{
+		{"NULL", "rows", "", NullElementError, &support.Grid{}, false},
+		{"empty", "rows", "{}", NullElementError, &support.Grid{}, false},
+		{"two dimensions", "rows", "{{1,2,3},{4,5,6}}", NullElementError, &support.Grid{Rows: rows([]int32{1, 2, 3}, []int32{4, 5, 6})}, false},
+		{"three dimensions", "planes", "{{{1,2},{3,4}},{{5,6},{7,8}}}", NullElementError, &support.Grid{Planes: []*support.Plane{
+			{Rows: rows([]int32{1, 2}, []int32{3, 4})},
+			{Rows: rows([]int32{5, 6}, []int32{7, 8})},
+		}}, false},
+		{"NULL element", "rows", "{{1,NULL},{3,4}}", NullElementError, nil, true},
+		{"skip NULL element", "rows", "{{1,NULL},{3,4}}", NullElementSkip, &support.Grid{Rows: rows([]int32{1}, []int32{3, 4})}, false},
+		{"zero NULL element", "rows", "{{1,NULL},{3,4}}", NullElementZero, &support.Grid{Rows: rows([]int32{1, 0}, []int32{3, 4})}, false},
+		{"too few dimensions", "rows", "{1,2}", NullElementError, nil, true},
+		{"too many dimensions", "rows", "{{{1}}}", NullElementError, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := (&support.Grid{}).ProtoReflect().Descriptor().Fields().ByName(tt.field)
+
+			v, err := (&Options{NullElements: tt.policy}).New(fd, pgtype.Undefined, string(tt.field), pgtype.Int4ArrayOID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := v.(*arrayValue); !ok {
+				t.Fatalf("Options.New() = %T, want %T", v, &arrayValue{})
+			}
+
+			var src []byte
+			if tt.src != "" {
+				src = []byte(tt.src)
+			}
+			if err = v.DecodeText(connInfo, src); err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(support.Grid)
+			err = v.SetTo(got.ProtoReflect())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("arrayValue.SetTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !proto.Equal(got, tt.want) {
+				t.Errorf("arrayValue.SetTo() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_arrayValue_emptyDimension(t *testing.T) {
+	tests := []struct {
+		name  string
+		field pr.Name
+		dims  []pgtype.ArrayDimension
+		want  *support.Grid
+	}{
+		{"first", "rows", []pgtype.ArrayDimension{{Length: 0, LowerBound: 1}, {Length: 3, LowerBound: 1}}, &support.Grid{}},
+		{"last", "rows", []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}, {Length: 0, LowerBound: 1}}, &support.Grid{Rows: rows(nil, nil)}},
+		{"inner", "planes", []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}, {Length: 0, LowerBound: 1}, {Length: 2, LowerBound: 1}}, &support.Grid{Planes: []*support.Plane{{}, {}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := (&support.Grid{}).ProtoReflect().Descriptor().Fields().ByName(tt.field)
+
+			v, err := new(Options).New(fd, pgtype.Undefined, string(tt.field), pgtype.Int4ArrayOID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			header := pgtype.ArrayHeader{ElementOID: pgtype.Int4OID, Dimensions: tt.dims}
+			if err = v.DecodeBinary(connInfo, header.EncodeBinary(connInfo, nil)); err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(support.Grid)
+			if err = v.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("arrayValue.SetTo() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_arrayValue_SetFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   pr.Name
+		msg     *support.Grid
+		want    string
+		wantErr bool
+	}{
+		{"empty", "rows", &support.Grid{}, "{}", false},
+		{"empty rows", "rows", &support.Grid{Rows: rows(nil, nil)}, "{}", false},
+		{"two dimensions", "rows", &support.Grid{Rows: rows([]int32{1, 2, 3}, []int32{4, 5, 6})}, "{{1,2,3},{4,5,6}}", false},
+		{"three dimensions", "planes", &support.Grid{Planes: []*support.Plane{
+			{Rows: rows([]int32{1, 2}, []int32{3, 4})},
+			{Rows: rows([]int32{5, 6}, []int32{7, 8})},
+		}}, "{{{1,2},{3,4}},{{5,6},{7,8}}}", false},
+		{"ragged", "rows", &support.Grid{Rows: rows([]int32{1, 2}, []int32{3})}, "", true},
+		{"ragged planes", "planes", &support.Grid{Planes: []*support.Plane{
+			{Rows: rows([]int32{1, 2})},
+			{Rows: rows([]int32{5, 6}, []int32{7, 8})},
+		}}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := (&support.Grid{}).ProtoReflect().Descriptor().Fields().ByName(tt.field)
+
+			v, err := new(Options).New(fd, pgtype.Present, string(tt.field), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = v.SetFrom(tt.msg.ProtoReflect())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("arrayValue.SetFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			buf, err := v.EncodeText(connInfo, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(buf); got != tt.want {
+				t.Errorf("arrayValue.SetFrom() = %s, want %s", got, tt.want)
+			}
+
+			// Round trip in binary format.
+			if buf, err = v.EncodeBinary(connInfo, nil); err != nil {
+				t.Fatal(err)
+			}
+			w, err := new(Options).New(fd, pgtype.Undefined, string(tt.field), pgtype.Int4ArrayOID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = w.DecodeBinary(connInfo, buf); err != nil {
+				t.Fatal(err)
+			}
+			got := new(support.Grid)
+			if err = w.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			want := tt.msg
+			if tt.want == "{}" {
+				want = &support.Grid{}
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("round trip =\n%v\nwant\n%v", got, want)
+			}
+		})
+	}
+}
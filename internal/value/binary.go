@@ -0,0 +1,134 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func unmarshalMessage(msg pr.Message, fd pr.FieldDescriptor, data []byte) (pr.Value, error) {
+	value := msg.NewField(fd)
+	if fd.IsList() {
+		value = pr.ValueOfMessage(value.List().NewElement().Message())
+	}
+
+	if err := proto.Unmarshal(data, value.Message().Interface()); err != nil {
+		return pr.Value{}, fmt.Errorf("value: field %s: %w", fd.FullName(), err)
+	}
+
+	return value, nil
+}
+
+func marshalMessage(fd pr.FieldDescriptor, value pr.Value) ([]byte, error) {
+	data, err := proto.Marshal(value.Message().Interface())
+	if err != nil {
+		return nil, fmt.Errorf("value: field %s: %w", fd.FullName(), err)
+	}
+
+	return data, nil
+}
+
+// binaryValue scans and writes message fields
+// in protocol buffers wire format from and to bytea columns.
+type binaryValue struct {
+	pgtype.Bytea
+	fd pr.FieldDescriptor
+}
+
+func (v *binaryValue) PGValue() pgtype.Value { return &v.Bytea }
+
+func (v *binaryValue) SetTo(msg pr.Message) error {
+	if v.Status != pgtype.Present {
+		return nil
+	}
+
+	value, err := unmarshalMessage(msg, v.fd, v.Bytes)
+	if err != nil {
+		return err
+	}
+
+	msg.Set(v.fd, value)
+	return nil
+}
+
+func (v *binaryValue) SetFrom(msg pr.Message) error {
+	data, err := marshalMessage(v.fd, msg.Get(v.fd))
+	if err != nil {
+		return err
+	}
+
+	return v.Set(data)
+}
+
+// binaryListValue scans and writes repeated message fields
+// in protocol buffers wire format from and to bytea array columns.
+type binaryListValue struct {
+	pgtype.ByteaArray
+	fd pr.FieldDescriptor
+}
+
+func (v *binaryListValue) PGValue() pgtype.Value { return &v.ByteaArray }
+
+func (v *binaryListValue) SetTo(msg pr.Message) error {
+	if v.Status != pgtype.Present {
+		return nil
+	}
+
+	pl := msg.NewField(v.fd).List()
+	for _, elem := range v.Elements {
+		value, err := unmarshalMessage(msg, v.fd, elem.Bytes)
+		if err != nil {
+			return err
+		}
+
+		pl.Append(value)
+	}
+
+	msg.Set(v.fd, pr.ValueOfList(pl))
+	return nil
+}
+
+func (v *binaryListValue) SetFrom(msg pr.Message) error {
+	pl := msg.Get(v.fd).List()
+	list := make([][]byte, pl.Len())
+
+	for i := range list {
+		data, err := marshalMessage(v.fd, pl.Get(i))
+		if err != nil {
+			return err
+		}
+
+		list[i] = data
+	}
+
+	return v.Set(list)
+}
+
+func newBinaryValue(fd pr.FieldDescriptor, status pgtype.Status) Value {
+	if fd.IsList() {
+		return &binaryListValue{pgtype.ByteaArray{Status: status}, fd}
+	}
+
+	return &binaryValue{pgtype.Bytea{Status: status}, fd}
+}
@@ -0,0 +1,103 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_binaryValue(t *testing.T) {
+	want := &support.Unsupported{Sup: &support.Supported{I32: 1, S: "foo"}}
+	fd := want.ProtoReflect().Descriptor().Fields().ByName("sup")
+
+	v := newBinaryValue(fd, pgtype.Null)
+	if err := v.SetFrom(want.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := proto.Marshal(want.GetSup())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.(*binaryValue).Bytes; string(got) != string(data) {
+		t.Errorf("binaryValue.SetFrom = %v, want %v", got, data)
+	}
+
+	got := &support.Unsupported{}
+	if err := v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(got, want) {
+		t.Errorf("binaryValue.SetTo =\n%v\nwant\n%v", got, want)
+	}
+
+	v.(*binaryValue).Bytes = []byte{0xff}
+	if err := v.SetTo(got.ProtoReflect()); err == nil {
+		t.Error("binaryValue.SetTo: expected error, got nil")
+	}
+}
+
+func Test_binaryValue_null(t *testing.T) {
+	got := &support.Unsupported{}
+	msg := got.ProtoReflect()
+
+	v := newBinaryValue(msg.Descriptor().Fields().ByName("sup"), pgtype.Null)
+	if err := v.SetTo(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Sup != nil {
+		t.Errorf("binaryValue.SetTo = %v, want nil", got.Sup)
+	}
+}
+
+func Test_binaryListValue(t *testing.T) {
+	want := &support.Supported{RTs: []*timestamppb.Timestamp{{Seconds: 1}, {Seconds: 2, Nanos: 3}}}
+	fd := want.ProtoReflect().Descriptor().Fields().ByName("r_ts")
+
+	v := newBinaryValue(fd, pgtype.Null)
+	if err := v.SetFrom(want.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(v.(*binaryListValue).Elements); n != 2 {
+		t.Fatalf("binaryListValue.SetFrom: len = %d, want 2", n)
+	}
+
+	got := &support.Supported{}
+	if err := v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(got, want) {
+		t.Errorf("binaryListValue.SetTo =\n%v\nwant\n%v", got, want)
+	}
+
+	v.(*binaryListValue).Elements[1].Bytes = []byte{0xff}
+	if err := v.SetTo(got.ProtoReflect()); err == nil {
+		t.Error("binaryListValue.SetTo: expected error, got nil")
+	}
+}
@@ -0,0 +1,128 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// connInfo is used to look up the data types known to pgtype.
+var connInfo = pgtype.NewConnInfo()
+
+// typeName returns the name of the data type with oid,
+// or the oid itself for unknown types.
+func typeName(oid uint32) string {
+	if dt, ok := connInfo.DataTypeForOID(oid); ok {
+		return dt.Name
+	}
+
+	return fmt.Sprint(oid)
+}
+
+// isIntegerKind reports whether k is any of the integer kinds.
+func isIntegerKind(k pr.Kind) bool {
+	switch k {
+	case pr.Int32Kind, pr.Sint32Kind, pr.Sfixed32Kind,
+		pr.Int64Kind, pr.Sint64Kind, pr.Sfixed64Kind,
+		pr.Uint32Kind, pr.Fixed32Kind,
+		pr.Uint64Kind, pr.Fixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+// columnTranscoder returns a transcoder for scanning a column of type oid
+// into a scalar field, or an array column into a repeated scalar field.
+// The transcoder's AssignTo method converts the column value to the field's type,
+// with range checks.
+//
+// Nil is returned when oid is 0 or of a type unknown to pgtype,
+// such as extension types like citext.
+// Such types are scanned in text format, which is handled by the default transcoder of the field kind.
+// An error is returned for known column types which are not compatible with the field.
+func columnTranscoder(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) (pgtype.ValueTranscoder, error) {
+	if _, ok := connInfo.DataTypeForOID(oid); !ok {
+		return nil, nil
+	}
+
+	kind := fd.Kind()
+
+	if fd.IsList() {
+		switch {
+		case oid == pgtype.BoolArrayOID && kind == pr.BoolKind:
+			return &pgtype.BoolArray{Status: status}, nil
+		case oid == pgtype.ByteaArrayOID && kind == pr.BytesKind:
+			return &pgtype.ByteaArray{Status: status}, nil
+		case oid == pgtype.Int2ArrayOID && isIntegerKind(kind):
+			return &pgtype.Int2Array{Status: status}, nil
+		case oid == pgtype.Int4ArrayOID && isIntegerKind(kind):
+			return &pgtype.Int4Array{Status: status}, nil
+		case oid == pgtype.Int8ArrayOID && isIntegerKind(kind):
+			return &pgtype.Int8Array{Status: status}, nil
+		case oid == pgtype.NumericArrayOID && (isIntegerKind(kind) || kind == pr.FloatKind || kind == pr.DoubleKind):
+			return &pgtype.NumericArray{Status: status}, nil
+		case oid == pgtype.Float4ArrayOID && (kind == pr.FloatKind || kind == pr.DoubleKind):
+			return &pgtype.Float4Array{Status: status}, nil
+		case oid == pgtype.Float8ArrayOID && (kind == pr.FloatKind || kind == pr.DoubleKind):
+			return &pgtype.Float8Array{Status: status}, nil
+		case oid == pgtype.TextArrayOID && kind == pr.StringKind:
+			return &pgtype.TextArray{Status: status}, nil
+		case oid == pgtype.VarcharArrayOID && kind == pr.StringKind:
+			return &pgtype.VarcharArray{Status: status}, nil
+		case oid == pgtype.BPCharArrayOID && kind == pr.StringKind:
+			return &pgtype.BPCharArray{Status: status}, nil
+		case oid == pgtype.UUIDArrayOID && kind == pr.StringKind:
+			return &pgtype.UUIDArray{Status: status}, nil
+		}
+	} else {
+		switch {
+		case oid == pgtype.BoolOID && kind == pr.BoolKind:
+			return &pgtype.Bool{Status: status}, nil
+		case oid == pgtype.ByteaOID && kind == pr.BytesKind:
+			return &pgtype.Bytea{Status: status}, nil
+		case oid == pgtype.Int2OID && isIntegerKind(kind):
+			return &pgtype.Int2{Status: status}, nil
+		case oid == pgtype.Int4OID && isIntegerKind(kind):
+			return &pgtype.Int4{Status: status}, nil
+		case oid == pgtype.Int8OID && isIntegerKind(kind):
+			return &pgtype.Int8{Status: status}, nil
+		case oid == pgtype.NumericOID && (isIntegerKind(kind) || kind == pr.FloatKind || kind == pr.DoubleKind):
+			return &pgtype.Numeric{Status: status}, nil
+		case oid == pgtype.Float4OID && (kind == pr.FloatKind || kind == pr.DoubleKind):
+			return &pgtype.Float4{Status: status}, nil
+		case oid == pgtype.Float8OID && (kind == pr.FloatKind || kind == pr.DoubleKind):
+			return &pgtype.Float8{Status: status}, nil
+		case (oid == pgtype.TextOID || oid == pgtype.VarcharOID || oid == pgtype.BPCharOID || oid == pgtype.NameOID) && kind == pr.StringKind:
+			return &pgtype.Text{Status: status}, nil
+		case oid == pgtype.UUIDOID && kind == pr.StringKind:
+			return &pgtype.UUID{Status: status}, nil
+		case oid == pgtype.JSONOID && kind == pr.StringKind:
+			return &pgtype.JSON{Status: status}, nil
+		case oid == pgtype.JSONBOID && kind == pr.StringKind:
+			return &pgtype.JSONB{Status: status}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("value: column of type %s can't be scanned into field %s of kind %s", typeName(oid), fd.FullName(), kind)
+}
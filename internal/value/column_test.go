@@ -0,0 +1,115 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func Test_columnTranscoder(t *testing.T) {
+	fields := new(support.Supported).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		name    string
+		field   string
+		oid     uint32
+		want    pgtype.ValueTranscoder
+		wantErr bool
+	}{
+		{"unknown oid", "i32", 0, nil, false},
+		{"unknown type", "s", 999999, nil, false},
+		{"smallint", "i32", pgtype.Int2OID, &pgtype.Int2{}, false},
+		{"bigint", "u32", pgtype.Int8OID, &pgtype.Int8{}, false},
+		{"numeric", "i64", pgtype.NumericOID, &pgtype.Numeric{}, false},
+		{"real", "d", pgtype.Float4OID, &pgtype.Float4{}, false},
+		{"varchar", "s", pgtype.VarcharOID, &pgtype.Text{}, false},
+		{"uuid", "s", pgtype.UUIDOID, &pgtype.UUID{}, false},
+		{"jsonb", "s", pgtype.JSONBOID, &pgtype.JSONB{}, false},
+		{"smallint array", "r_i64", pgtype.Int2ArrayOID, &pgtype.Int2Array{}, false},
+		{"numeric array", "r_f", pgtype.NumericArrayOID, &pgtype.NumericArray{}, false},
+		{"uuid array", "r_s", pgtype.UUIDArrayOID, &pgtype.UUIDArray{}, false},
+		{"text into integer", "i32", pgtype.TextOID, nil, true},
+		{"float into integer", "i64", pgtype.Float8OID, nil, true},
+		{"integer array into scalar", "i32", pgtype.Int4ArrayOID, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := columnTranscoder(fields.ByName(pr.Name(tt.field)), pgtype.Undefined, tt.oid)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("columnTranscoder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("columnTranscoder() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptions_New_column(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		oid     uint32
+		src     string
+		want    *support.Supported
+		wantErr bool
+	}{
+		{"smallint into int32", "i32", pgtype.Int2OID, "12", &support.Supported{I32: 12}, false},
+		{"bigint into int32", "i32", pgtype.Int8OID, "-34", &support.Supported{I32: -34}, false},
+		{"bigint overflow into int32", "i32", pgtype.Int8OID, "9000000000", nil, true},
+		{"numeric into int64", "i64", pgtype.NumericOID, "9000000000", &support.Supported{I64: 9000000000}, false},
+		{"fractional numeric into int64", "i64", pgtype.NumericOID, "1.5", nil, true},
+		{"real into double", "d", pgtype.Float4OID, "1.5", &support.Supported{D: 1.5}, false},
+		{"numeric into float", "f", pgtype.NumericOID, "2.25", &support.Supported{F: 2.25}, false},
+		{"uuid into string", "s", pgtype.UUIDOID, "3f2504e0-4f89-11d3-9a0c-0305e82c3301", &support.Supported{S: "3f2504e0-4f89-11d3-9a0c-0305e82c3301"}, false},
+		{"varchar into string", "s", pgtype.VarcharOID, "foo", &support.Supported{S: "foo"}, false},
+		{"unknown type into string", "s", 999999, "foo", &support.Supported{S: "foo"}, false},
+		{"smallint array into int64", "r_i64", pgtype.Int2ArrayOID, "{1,2,3}", &support.Supported{RI64: []int64{1, 2, 3}}, false},
+		{"bigint array overflow into int32", "r_i32", pgtype.Int8ArrayOID, "{1,9000000000}", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(support.Supported)
+			msg := got.ProtoReflect()
+
+			v, err := new(Options).New(msg.Descriptor().Fields().ByName(pr.Name(tt.field)), pgtype.Undefined, tt.field, tt.oid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = v.(pgtype.TextDecoder).DecodeText(nil, []byte(tt.src)); err != nil {
+				t.Fatal(err)
+			}
+
+			err = v.SetTo(msg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Value.SetTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !proto.Equal(got, tt.want) {
+				t.Errorf("Value.SetTo() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,498 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// recordArrayOID is the oid of record[], as returned by array_agg(row(...)).
+// It is not known to pgtype, so it is scanned in text format.
+const recordArrayOID = 2287
+
+var errUndefined = errors.New("value: cannot encode status undefined")
+
+// attributeFields returns the fields for the attributes of the composite type with oid.
+// If ci has a registered pgtype.CompositeType for oid, attributes are matched to fields by name.
+// Otherwise, such as for anonymous records, attributes are matched by position
+// to the fields in the order of declaration.
+func (o *Options) attributeFields(ci *pgtype.ConnInfo, fields pr.FieldDescriptors, oid uint32) ([]pr.FieldDescriptor, error) {
+	if ci != nil {
+		if dt, ok := ci.DataTypeForOID(oid); ok {
+			if ct, ok := dt.Value.(*pgtype.CompositeType); ok {
+				attrs := ct.Fields()
+				fds := make([]pr.FieldDescriptor, len(attrs))
+
+				for i, attr := range attrs {
+					if fds[i] = o.Field(fields, attr.Name); fds[i] == nil {
+						return nil, fmt.Errorf("value: unknown field for attribute %s of composite type %s", attr.Name, ct.TypeName())
+					}
+				}
+
+				return fds, nil
+			}
+		}
+	}
+
+	fds := make([]pr.FieldDescriptor, fields.Len())
+	for i := range fds {
+		fds[i] = fields.Get(i)
+	}
+
+	return fds, nil
+}
+
+// attributeValue returns a Value for a composite attribute, mapped to fd.
+// The Encoding set for the column name of fd applies.
+// Otherwise, message fields of types which are not registered or ranges
+// are decoded and encoded as composites.
+func (o *Options) attributeValue(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) (Value, error) {
+	column := o.Column(fd)
+
+	if md := fd.Message(); md != nil && !fd.IsMap() && o.encoding(column) == Auto {
+		if _, ok := registered.lookup(md.FullName()); !ok && !isRange(md) {
+			return o.newCompositeValue(fd, status, oid), nil
+		}
+	}
+
+	return o.New(fd, status, column, oid)
+}
+
+// decodeComposite decodes the attributes of a composite value in text or binary format.
+func (o *Options) decodeComposite(ci *pgtype.ConnInfo, md pr.MessageDescriptor, oid uint32, format int16, src []byte) ([]Value, error) {
+	fds, err := o.attributeFields(ci, md.Fields(), oid)
+	if err != nil {
+		return nil, err
+	}
+
+	var attrs []Value
+
+	next := func(attrOID uint32) (Value, error) {
+		i := len(attrs)
+		if i >= len(fds) {
+			return nil, fmt.Errorf("value: composite has more attributes than fields in %s", md.FullName())
+		}
+
+		v, err := o.attributeValue(fds[i], pgtype.Undefined, attrOID)
+		if err != nil {
+			return nil, err
+		}
+
+		attrs = append(attrs, v)
+		return v, nil
+	}
+
+	if format == pgtype.BinaryFormatCode {
+		scanner := pgtype.NewCompositeBinaryScanner(ci, src)
+		for scanner.Next() {
+			v, err := next(scanner.OID())
+			if err != nil {
+				return nil, err
+			}
+			if err = v.DecodeBinary(ci, scanner.Bytes()); err != nil {
+				return nil, fmt.Errorf("value: attribute %s: %w", fds[len(attrs)-1].Name(), err)
+			}
+		}
+
+		return attrs, scanner.Err()
+	}
+
+	scanner := pgtype.NewCompositeTextScanner(ci, src)
+	for scanner.Next() {
+		v, err := next(0)
+		if err != nil {
+			return nil, err
+		}
+		if err = v.DecodeText(ci, scanner.Bytes()); err != nil {
+			return nil, fmt.Errorf("value: attribute %s: %w", fds[len(attrs)-1].Name(), err)
+		}
+	}
+
+	return attrs, scanner.Err()
+}
+
+// setComposite sets the decoded attributes in msg.
+func setComposite(msg pr.Message, attrs []Value) error {
+	for _, v := range attrs {
+		if err := v.SetTo(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeComposite appends msg as composite literal in text format to buf.
+// Fields are encoded in order of declaration, which must match the order of the attributes.
+// Unset message and optional fields are encoded as NULL, other unset fields as their zero value.
+func (o *Options) encodeComposite(ci *pgtype.ConnInfo, msg pr.Message, buf []byte) ([]byte, error) {
+	fields := msg.Descriptor().Fields()
+	builder := pgtype.NewCompositeTextBuilder(ci, buf)
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		if fd.Message() != nil && !fd.IsList() && !fd.IsMap() && !msg.Has(fd) {
+			builder.AppendValue(nil)
+			continue
+		}
+
+		v, err := o.attributeValue(fd, pgtype.Present, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if msg.Has(fd) {
+			if err = v.SetFrom(msg); err != nil {
+				return nil, err
+			}
+		}
+
+		builder.AppendEncoder(v)
+	}
+
+	return builder.Finish()
+}
+
+// compositeValue scans and writes a message field from and to a composite type or record column.
+// Writing is done in text format, as the attribute types are not known.
+type compositeValue struct {
+	o      *Options
+	fd     pr.FieldDescriptor
+	oid    uint32
+	status pgtype.Status
+	attrs  []Value    // Scanned attributes.
+	msg    pr.Message // Message to write.
+}
+
+func (v *compositeValue) PGValue() pgtype.Value { return v }
+
+// PreferredParamFormat returns the text format code.
+func (v *compositeValue) PreferredParamFormat() int16 { return pgtype.TextFormatCode }
+
+func (v *compositeValue) Set(src interface{}) error {
+	if src == nil {
+		*v = compositeValue{o: v.o, fd: v.fd, oid: v.oid, status: pgtype.Null}
+		return nil
+	}
+
+	return fmt.Errorf("value: cannot set %T to composite field %s", src, v.fd.FullName())
+}
+
+func (v *compositeValue) Get() interface{} {
+	switch v.status {
+	case pgtype.Present:
+		return v
+	case pgtype.Null:
+		return nil
+	default:
+		return v.status
+	}
+}
+
+func (v *compositeValue) AssignTo(dst interface{}) error {
+	return fmt.Errorf("value: cannot assign composite field %s to %T", v.fd.FullName(), dst)
+}
+
+func (v *compositeValue) decode(ci *pgtype.ConnInfo, format int16, src []byte) (err error) {
+	v.msg = nil
+
+	if src == nil {
+		v.status, v.attrs = pgtype.Null, nil
+		return nil
+	}
+
+	if v.attrs, err = v.o.decodeComposite(ci, v.fd.Message(), v.oid, format, src); err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+
+	v.status = pgtype.Present
+	return nil
+}
+
+func (v *compositeValue) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	return v.decode(ci, pgtype.TextFormatCode, src)
+}
+
+func (v *compositeValue) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	return v.decode(ci, pgtype.BinaryFormatCode, src)
+}
+
+func (v *compositeValue) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	switch v.status {
+	case pgtype.Null:
+		return nil, nil
+	case pgtype.Undefined:
+		return nil, errUndefined
+	}
+
+	msg := v.msg
+	if msg == nil {
+		msg = dynamicpb.NewMessage(v.fd.Message())
+	}
+
+	return v.o.encodeComposite(ci, msg, buf)
+}
+
+func (v *compositeValue) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	return nil, fmt.Errorf("value: composite field %s only supports text format", v.fd.FullName())
+}
+
+func (v *compositeValue) SetTo(msg pr.Message) error {
+	if v.status != pgtype.Present {
+		return nil
+	}
+
+	m := msg.NewField(v.fd).Message()
+	if err := setComposite(m, v.attrs); err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+
+	msg.Set(v.fd, pr.ValueOfMessage(m))
+	return nil
+}
+
+func (v *compositeValue) SetFrom(msg pr.Message) error {
+	v.status, v.attrs, v.msg = pgtype.Present, nil, msg.Get(v.fd).Message()
+	return nil
+}
+
+// compositeListValue scans and writes a repeated message field
+// from and to an array of a composite type or record[] column.
+// NULL array elements follow the NullElements policy of the Options.
+// Writing is done in text format, as the attribute types are not known.
+type compositeListValue struct {
+	o        *Options
+	fd       pr.FieldDescriptor
+	status   pgtype.Status
+	elements [][]Value // Scanned attributes of each element.
+	list     pr.List   // List to write.
+}
+
+func (v *compositeListValue) PGValue() pgtype.Value { return v }
+
+// PreferredParamFormat returns the text format code.
+func (v *compositeListValue) PreferredParamFormat() int16 { return pgtype.TextFormatCode }
+
+func (v *compositeListValue) Set(src interface{}) error {
+	if src == nil {
+		*v = compositeListValue{o: v.o, fd: v.fd, status: pgtype.Null}
+		return nil
+	}
+
+	return fmt.Errorf("value: cannot set %T to composite field %s", src, v.fd.FullName())
+}
+
+func (v *compositeListValue) Get() interface{} {
+	switch v.status {
+	case pgtype.Present:
+		return v
+	case pgtype.Null:
+		return nil
+	default:
+		return v.status
+	}
+}
+
+func (v *compositeListValue) AssignTo(dst interface{}) error {
+	return fmt.Errorf("value: cannot assign composite field %s to %T", v.fd.FullName(), dst)
+}
+
+func (v *compositeListValue) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	v.list, v.elements = nil, nil
+
+	if src == nil {
+		v.status = pgtype.Null
+		return nil
+	}
+
+	uta, err := pgtype.ParseUntypedTextArray(string(src))
+	if err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+	if len(uta.Dimensions) > 1 {
+		return fmt.Errorf("value: field %s: multi-dimensional arrays not supported", v.fd.FullName())
+	}
+
+	for i, elem := range uta.Elements {
+		if !uta.Quoted[i] && elem == "NULL" {
+			if err = v.nullElement(i); err != nil {
+				return err
+			}
+			continue
+		}
+
+		attrs, err := v.o.decodeComposite(ci, v.fd.Message(), 0, pgtype.TextFormatCode, []byte(elem))
+		if err != nil {
+			return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+		}
+
+		v.elements = append(v.elements, attrs)
+	}
+
+	v.status = pgtype.Present
+	return nil
+}
+
+func (v *compositeListValue) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	v.list, v.elements = nil, nil
+
+	if src == nil {
+		v.status = pgtype.Null
+		return nil
+	}
+
+	var header pgtype.ArrayHeader
+	rp, err := header.DecodeBinary(ci, src)
+	if err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+	if len(header.Dimensions) > 1 {
+		return fmt.Errorf("value: field %s: multi-dimensional arrays not supported", v.fd.FullName())
+	}
+
+	n := 0
+	if len(header.Dimensions) == 1 {
+		n = int(header.Dimensions[0].Length)
+	}
+
+	for i := 0; i < n; i++ {
+		if len(src[rp:]) < 4 {
+			return fmt.Errorf("value: field %s: array incomplete", v.fd.FullName())
+		}
+		elemLen := int(int32(binary.BigEndian.Uint32(src[rp:])))
+		rp += 4
+
+		if elemLen < 0 {
+			if err = v.nullElement(i); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(src[rp:]) < elemLen {
+			return fmt.Errorf("value: field %s: array incomplete", v.fd.FullName())
+		}
+
+		attrs, err := v.o.decodeComposite(ci, v.fd.Message(), uint32(header.ElementOID), pgtype.BinaryFormatCode, src[rp:rp+elemLen])
+		if err != nil {
+			return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+		}
+		rp += elemLen
+
+		v.elements = append(v.elements, attrs)
+	}
+
+	v.status = pgtype.Present
+	return nil
+}
+
+// nullElement handles the NULL array element at index i, following the NullElements policy.
+// Zero elements are stored without attributes, resulting in an empty message.
+func (v *compositeListValue) nullElement(i int) error {
+	var policy NullElementPolicy
+	if v.o != nil {
+		policy = v.o.NullElements
+	}
+
+	switch policy {
+	case NullElementSkip:
+	case NullElementZero:
+		v.elements = append(v.elements, nil)
+	default:
+		return fmt.Errorf("value: field %s: NULL array element %d", v.fd.FullName(), i)
+	}
+
+	return nil
+}
+
+var compositeArrayQuoter = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func (v *compositeListValue) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	switch v.status {
+	case pgtype.Null:
+		return nil, nil
+	case pgtype.Undefined:
+		return nil, errUndefined
+	}
+
+	buf = append(buf, '{')
+
+	for i := 0; v.list != nil && i < v.list.Len(); i++ {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+
+		elem, err := v.o.encodeComposite(ci, v.list.Get(i).Message(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+		}
+
+		buf = append(buf, '"')
+		buf = append(buf, compositeArrayQuoter.Replace(string(elem))...)
+		buf = append(buf, '"')
+	}
+
+	return append(buf, '}'), nil
+}
+
+func (v *compositeListValue) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	return nil, fmt.Errorf("value: composite field %s only supports text format", v.fd.FullName())
+}
+
+func (v *compositeListValue) SetTo(msg pr.Message) error {
+	if v.status != pgtype.Present {
+		return nil
+	}
+
+	pl := msg.NewField(v.fd).List()
+	for _, attrs := range v.elements {
+		m := pl.NewElement().Message()
+		if err := setComposite(m, attrs); err != nil {
+			return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+		}
+
+		pl.Append(pr.ValueOfMessage(m))
+	}
+
+	msg.Set(v.fd, pr.ValueOfList(pl))
+	return nil
+}
+
+func (v *compositeListValue) SetFrom(msg pr.Message) error {
+	v.status, v.elements, v.list = pgtype.Present, nil, msg.Get(v.fd).List()
+	return nil
+}
+
+// newCompositeValue returns a Value for a (repeated) message field,
+// in a composite type or record (array) column with oid.
+func (o *Options) newCompositeValue(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) Value {
+	if fd.IsList() {
+		return &compositeListValue{o: o, fd: fd, status: status}
+	}
+
+	return &compositeValue{o: o, fd: fd, oid: oid, status: status}
+}
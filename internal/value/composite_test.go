@@ -0,0 +1,559 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func childBinary(t *testing.T, id int32, name string) []byte {
+	t.Helper()
+
+	b := pgtype.NewCompositeBinaryBuilder(connInfo, nil)
+	b.AppendValue(pgtype.Int4OID, id)
+	b.AppendValue(pgtype.TextOID, name)
+	b.AppendValue(pgtype.TimestamptzOID, nil)
+
+	data, err := b.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func Test_compositeValue_DecodeText(t *testing.T) {
+	fd := (&support.Parent{}).ProtoReflect().Descriptor().Fields().ByName("favorite")
+
+	tests := []struct {
+		name    string
+		src     string
+		want    *support.Parent
+		wantErr bool
+	}{
+		{
+			"all",
+			`(1,foo,"2021-01-02 03:04:05+00")`,
+			&support.Parent{Favorite: &support.Child{Id: 1, Name: "foo", Born: &timestamppb.Timestamp{Seconds: 1609556645}}},
+			false,
+		},
+		{
+			"NULL attributes",
+			`(2,,)`,
+			&support.Parent{Favorite: &support.Child{Id: 2}},
+			false,
+		},
+		{
+			"too many attributes",
+			`(1,foo,,bar)`,
+			nil,
+			true,
+		},
+		{
+			"decode error",
+			`(foo,bar,)`,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := new(Options).newCompositeValue(fd, pgtype.Undefined, pgtype.RecordOID)
+
+			err := v.DecodeText(connInfo, []byte(tt.src))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compositeValue.DecodeText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := &support.Parent{}
+			if err = v.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("compositeValue.SetTo =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_compositeValue_DecodeBinary(t *testing.T) {
+	fd := (&support.Parent{}).ProtoReflect().Descriptor().Fields().ByName("favorite")
+
+	v := new(Options).newCompositeValue(fd, pgtype.Undefined, pgtype.RecordOID)
+	if err := v.DecodeBinary(connInfo, childBinary(t, 3, "bar")); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &support.Parent{}
+	if err := v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &support.Parent{Favorite: &support.Child{Id: 3, Name: "bar"}}
+	if !proto.Equal(got, want) {
+		t.Errorf("compositeValue.SetTo =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func Test_compositeValue_null(t *testing.T) {
+	fd := (&support.Parent{}).ProtoReflect().Descriptor().Fields().ByName("favorite")
+
+	v := new(Options).newCompositeValue(fd, pgtype.Undefined, pgtype.RecordOID)
+	if err := v.DecodeText(connInfo, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &support.Parent{}
+	if err := v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+	if got.Favorite != nil {
+		t.Errorf("compositeValue.SetTo = %v, want nil", got.Favorite)
+	}
+
+	buf, err := v.EncodeText(connInfo, nil)
+	if err != nil || buf != nil {
+		t.Errorf("compositeValue.EncodeText = %q, %v, want nil, nil", buf, err)
+	}
+}
+
+func Test_compositeValue_named(t *testing.T) {
+	const oid = 100000
+
+	ci := pgtype.NewConnInfo()
+	ct, err := pgtype.NewCompositeType("child", []pgtype.CompositeTypeField{
+		{Name: "name", OID: pgtype.TextOID},
+		{Name: "id", OID: pgtype.Int4OID},
+	}, ci)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ci.RegisterDataType(pgtype.DataType{Value: ct, Name: "child", OID: oid})
+
+	fd := (&support.Parent{}).ProtoReflect().Descriptor().Fields().ByName("favorite")
+
+	v, err := new(Options).New(fd, pgtype.Undefined, "favorite", oid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = v.DecodeText(ci, []byte(`(foo,4)`)); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &support.Parent{}
+	if err = v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &support.Parent{Favorite: &support.Child{Id: 4, Name: "foo"}}
+	if !proto.Equal(got, want) {
+		t.Errorf("compositeValue.SetTo =\n%v\nwant\n%v", got, want)
+	}
+
+	ct, err = pgtype.NewCompositeType("other", []pgtype.CompositeTypeField{
+		{Name: "nope", OID: pgtype.TextOID},
+	}, ci)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ci.RegisterDataType(pgtype.DataType{Value: ct, Name: "other", OID: oid + 1})
+
+	v = new(Options).newCompositeValue(fd, pgtype.Undefined, oid+1)
+	if err = v.DecodeText(ci, []byte(`(foo)`)); err == nil {
+		t.Error("compositeValue.DecodeText: expected error, got nil")
+	}
+}
+
+func Test_compositeValue_EncodeText(t *testing.T) {
+	fd := (&support.Parent{}).ProtoReflect().Descriptor().Fields().ByName("favorite")
+
+	tests := []struct {
+		name   string
+		msg    *support.Parent
+		status pgtype.Status
+	}{
+		{
+			"all",
+			&support.Parent{Favorite: &support.Child{Id: 1, Name: `a "quoted", \escaped (name)`, Born: &timestamppb.Timestamp{Seconds: 1609556645}}},
+			pgtype.Null,
+		},
+		{
+			"empty",
+			&support.Parent{Favorite: &support.Child{}},
+			pgtype.Null,
+		},
+		{
+			"unset, zero",
+			&support.Parent{},
+			pgtype.Present,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := new(Options).newCompositeValue(fd, tt.status, 0)
+			if tt.msg.Favorite != nil {
+				if err := v.SetFrom(tt.msg.ProtoReflect()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			buf, err := v.EncodeText(connInfo, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := new(Options).newCompositeValue(fd, pgtype.Undefined, pgtype.RecordOID)
+			if err = r.DecodeText(connInfo, buf); err != nil {
+				t.Fatalf("DecodeText(%s): %v", buf, err)
+			}
+
+			got := &support.Parent{}
+			if err = r.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+
+			want := &support.Parent{Favorite: &support.Child{}}
+			if tt.msg.Favorite != nil {
+				want = tt.msg
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("round trip of %s =\n%v\nwant\n%v", buf, got, want)
+			}
+		})
+	}
+
+	v := new(Options).newCompositeValue(fd, pgtype.Undefined, 0)
+	if _, err := v.EncodeText(connInfo, nil); err == nil {
+		t.Error("compositeValue.EncodeText: expected error, got nil")
+	}
+	if _, err := v.EncodeBinary(connInfo, nil); err == nil {
+		t.Error("compositeValue.EncodeBinary: expected error, got nil")
+	}
+}
+
+func Test_compositeValue_attributes(t *testing.T) {
+	fd := (&support.Schedule{}).ProtoReflect().Descriptor().Fields().ByName("slot")
+
+	tests := []struct {
+		name string
+		o    *Options
+		src  string
+		want *support.Schedule
+	}{
+		{
+			"range",
+			new(Options),
+			`(1,"[1,10)")`,
+			&support.Schedule{Slot: &support.Slot{Id: 1, Seats: &support.IntRange{Lower: proto.Int64(1), Upper: proto.Int64(10)}}},
+		},
+		{
+			"empty range",
+			new(Options),
+			`(2,empty)`,
+			&support.Schedule{Slot: &support.Slot{Id: 2, Seats: &support.IntRange{Empty: true}}},
+		},
+		{
+			"column encoding",
+			&Options{Encodings: map[string]Encoding{"seats": JSON}},
+			`(3,"{""lower"": 5}")`,
+			&support.Schedule{Slot: &support.Slot{Id: 3, Seats: &support.IntRange{Lower: proto.Int64(5)}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.o.newCompositeValue(fd, pgtype.Undefined, pgtype.RecordOID)
+			if err := v.DecodeText(connInfo, []byte(tt.src)); err != nil {
+				t.Fatal(err)
+			}
+
+			got := &support.Schedule{}
+			if err := v.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("compositeValue.SetTo =\n%v\nwant\n%v", got, tt.want)
+			}
+
+			w := tt.o.newCompositeValue(fd, pgtype.Null, 0)
+			if err := w.SetFrom(tt.want.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			buf, err := w.EncodeText(connInfo, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := tt.o.newCompositeValue(fd, pgtype.Undefined, pgtype.RecordOID)
+			if err = r.DecodeText(connInfo, buf); err != nil {
+				t.Fatalf("DecodeText(%s): %v", buf, err)
+			}
+			got = &support.Schedule{}
+			if err = r.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("round trip of %s =\n%v\nwant\n%v", buf, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_compositeListValue_DecodeText(t *testing.T) {
+	fd := (&support.Parent{}).ProtoReflect().Descriptor().Fields().ByName("children")
+
+	tests := []struct {
+		name    string
+		src     string
+		want    *support.Parent
+		wantErr bool
+	}{
+		{
+			"elements",
+			`{"(1,foo,)","(2,\"bar baz\",)"}`,
+			&support.Parent{Children: []*support.Child{{Id: 1, Name: "foo"}, {Id: 2, Name: "bar baz"}}},
+			false,
+		},
+		{
+			"empty",
+			`{}`,
+			&support.Parent{},
+			false,
+		},
+		{
+			"NULL element",
+			`{"(1,foo,)",NULL}`,
+			nil,
+			true,
+		},
+		{
+			"multi-dimensional",
+			`{{"(1,foo,)"},{"(2,bar,)"}}`,
+			nil,
+			true,
+		},
+		{
+			"decode error",
+			`{"(foo,bar,)"}`,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := new(Options).New(fd, pgtype.Undefined, "children", recordArrayOID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = v.DecodeText(connInfo, []byte(tt.src))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compositeListValue.DecodeText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := &support.Parent{}
+			if err = v.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("compositeListValue.SetTo =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+// arrayBinary returns a record[] array in binary format, with NULL for nil elements.
+func arrayBinary(elems ...[]byte) []byte {
+	header := pgtype.ArrayHeader{
+		ElementOID: pgtype.RecordOID,
+		Dimensions: []pgtype.ArrayDimension{{Length: int32(len(elems)), LowerBound: 1}},
+	}
+	buf := header.EncodeBinary(connInfo, nil)
+
+	for _, elem := range elems {
+		if elem == nil {
+			buf = append(buf, 0xff, 0xff, 0xff, 0xff)
+			continue
+		}
+		n := make([]byte, 4)
+		binary.BigEndian.PutUint32(n, uint32(len(elem)))
+		buf = append(append(buf, n...), elem...)
+	}
+
+	return buf
+}
+
+func Test_compositeListValue_DecodeBinary(t *testing.T) {
+	fd := (&support.Parent{}).ProtoReflect().Descriptor().Fields().ByName("children")
+
+	v := new(Options).newCompositeValue(fd, pgtype.Undefined, recordArrayOID)
+	if err := v.DecodeBinary(connInfo, arrayBinary(childBinary(t, 1, "foo"), childBinary(t, 2, "bar"))); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &support.Parent{}
+	if err := v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &support.Parent{Children: []*support.Child{{Id: 1, Name: "foo"}, {Id: 2, Name: "bar"}}}
+	if !proto.Equal(got, want) {
+		t.Errorf("compositeListValue.SetTo =\n%v\nwant\n%v", got, want)
+	}
+
+	if err := v.DecodeBinary(connInfo, arrayBinary(childBinary(t, 1, "foo"), nil)); err == nil {
+		t.Error("compositeListValue.DecodeBinary: expected error, got nil")
+	}
+
+	data := arrayBinary(childBinary(t, 1, "foo"))
+	if err := v.DecodeBinary(connInfo, data[:len(data)-2]); err == nil {
+		t.Error("compositeListValue.DecodeBinary: expected error, got nil")
+	}
+}
+
+func Test_compositeListValue_nullElements(t *testing.T) {
+	fd := (&support.Parent{}).ProtoReflect().Descriptor().Fields().ByName("children")
+
+	tests := []struct {
+		name    string
+		policy  NullElementPolicy
+		want    *support.Parent
+		wantErr bool
+	}{
+		{"error", NullElementError, nil, true},
+		{"skip", NullElementSkip, &support.Parent{Children: []*support.Child{{Id: 1, Name: "foo"}}}, false},
+		{"zero", NullElementZero, &support.Parent{Children: []*support.Child{{}, {Id: 1, Name: "foo"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := (&Options{NullElements: tt.policy}).newCompositeValue(fd, pgtype.Undefined, recordArrayOID)
+
+			decoders := map[string]func() error{
+				"text":   func() error { return v.DecodeText(connInfo, []byte(`{NULL,"(1,foo,)"}`)) },
+				"binary": func() error { return v.DecodeBinary(connInfo, arrayBinary(nil, childBinary(t, 1, "foo"))) },
+			}
+			for format, decode := range decoders {
+				err := decode()
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("compositeListValue.Decode %s error = %v, wantErr %v", format, err, tt.wantErr)
+				}
+				if err != nil {
+					continue
+				}
+
+				got := &support.Parent{}
+				if err = v.SetTo(got.ProtoReflect()); err != nil {
+					t.Fatal(err)
+				}
+				if !proto.Equal(got, tt.want) {
+					t.Errorf("compositeListValue.SetTo %s =\n%v\nwant\n%v", format, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_compositeListValue_EncodeText(t *testing.T) {
+	msg := &support.Parent{Children: []*support.Child{
+		{Id: 1, Name: `a "quoted", \escaped {name}`},
+		{Id: 2, Born: &timestamppb.Timestamp{Seconds: 1609556645}},
+	}}
+	fd := msg.ProtoReflect().Descriptor().Fields().ByName("children")
+
+	v := new(Options).newCompositeValue(fd, pgtype.Null, 0)
+	if err := v.SetFrom(msg.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := v.EncodeText(connInfo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := new(Options).newCompositeValue(fd, pgtype.Undefined, recordArrayOID)
+	if err = r.DecodeText(connInfo, buf); err != nil {
+		t.Fatalf("DecodeText(%s): %v", buf, err)
+	}
+
+	got := &support.Parent{}
+	if err = r.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, msg) {
+		t.Errorf("round trip of %s =\n%v\nwant\n%v", buf, got, msg)
+	}
+
+	v = new(Options).newCompositeValue(fd, pgtype.Present, 0)
+	if buf, err = v.EncodeText(connInfo, nil); err != nil || string(buf) != "{}" {
+		t.Errorf("compositeListValue.EncodeText = %q, %v, want {}, nil", buf, err)
+	}
+}
+
+func TestOptions_messageEncoding(t *testing.T) {
+	fields := (&support.Parent{}).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		name    string
+		opts    *Options
+		field   pr.Name
+		oid     uint32
+		want    Value
+		wantErr bool
+	}{
+		{"record", nil, "favorite", pgtype.RecordOID, &compositeValue{}, false},
+		{"record array", nil, "children", recordArrayOID, &compositeListValue{}, false},
+		{"record, JSON", &Options{MessageEncoding: JSON}, "favorite", pgtype.RecordOID, &compositeValue{}, false},
+		{"user defined", nil, "favorite", 100000, &compositeValue{}, false},
+		{"user defined, JSON", &Options{MessageEncoding: JSON}, "favorite", 100000, &jsonValue{}, false},
+		{"jsonb", nil, "favorite", pgtype.JSONBOID, &jsonValue{}, false},
+		{"json, Binary", &Options{MessageEncoding: Binary}, "children", pgtype.JSONOID, &jsonValue{}, false},
+		{"bytea", nil, "favorite", pgtype.ByteaOID, nil, true},
+		{"unknown oid", nil, "favorite", 0, nil, true},
+		{"encoding", &Options{Encodings: map[string]Encoding{"favorite": Composite}}, "favorite", 0, &compositeValue{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.New(fields.ByName(tt.field), pgtype.Undefined, string(tt.field), tt.oid)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Options.New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if gt, wt := fmt.Sprintf("%T", got), fmt.Sprintf("%T", tt.want); gt != wt {
+				t.Errorf("Options.New() = %s, want %s", gt, wt)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const microsecondsPerSecond = 1000000
+
+// intervalToDuration converts an interval to a Duration message.
+// Months and days can't be represented exactly, as their length varies,
+// and result in an error.
+func intervalToDuration(iv pgtype.Interval) (*durationpb.Duration, error) {
+	if iv.Months != 0 || iv.Days != 0 {
+		return nil, fmt.Errorf("value: interval of %d months, %d days and %d microseconds can't be represented exactly as Duration", iv.Months, iv.Days, iv.Microseconds)
+	}
+
+	d := &durationpb.Duration{
+		Seconds: iv.Microseconds / microsecondsPerSecond,
+		Nanos:   int32(iv.Microseconds%microsecondsPerSecond) * 1000,
+	}
+
+	return d, d.CheckValid()
+}
+
+// durationToInterval converts a Duration message to an interval,
+// truncated to microsecond precision.
+func durationToInterval(d *durationpb.Duration) (pgtype.Interval, error) {
+	if err := d.CheckValid(); err != nil {
+		return pgtype.Interval{}, err
+	}
+
+	return pgtype.Interval{
+		Microseconds: d.GetSeconds()*microsecondsPerSecond + int64(d.GetNanos()/1000),
+		Status:       pgtype.Present,
+	}, nil
+}
+
+type durationValue struct {
+	pgtype.Interval
+	fd pr.FieldDescriptor
+}
+
+func (v *durationValue) PGValue() pgtype.Value { return &v.Interval }
+
+func (v *durationValue) SetTo(msg pr.Message) error {
+	if v.Status != pgtype.Present {
+		return nil
+	}
+
+	d, err := intervalToDuration(v.Interval)
+	if err != nil {
+		return err
+	}
+
+	msg.Set(v.fd, pr.ValueOfMessage(d.ProtoReflect()))
+	return nil
+}
+
+func (v *durationValue) SetFrom(msg pr.Message) (err error) {
+	v.Interval, err = durationToInterval(concreteMessage(msg.Get(v.fd).Message()).(*durationpb.Duration))
+	return err
+}
+
+// durationInterval is an interval array element,
+// which can be set from a Duration message or an Interval.
+type durationInterval struct {
+	pgtype.Interval
+}
+
+func (e *durationInterval) Set(src interface{}) (err error) {
+	switch src := src.(type) {
+	case *durationpb.Duration:
+		e.Interval, err = durationToInterval(src)
+		return err
+	case pgtype.Interval:
+		e.Interval = src
+		return nil
+	default:
+		return e.Interval.Set(src)
+	}
+}
+
+func newIntervalArray() *pgtype.ArrayType {
+	return pgtype.NewArrayType("_interval", pgtype.IntervalOID, func() pgtype.ValueTranscoder {
+		return new(durationInterval)
+	})
+}
+
+type durationListValue struct {
+	*pgtype.ArrayType
+	fd pr.FieldDescriptor
+}
+
+func (v *durationListValue) PGValue() pgtype.Value { return v.ArrayType }
+
+func (v *durationListValue) SetTo(msg pr.Message) error {
+	elements, ok := v.Get().([]interface{})
+	if !ok {
+		return nil
+	}
+
+	pl := msg.NewField(v.fd).List()
+
+	for i, elem := range elements {
+		iv, ok := elem.(pgtype.Interval)
+		if !ok {
+			return fmt.Errorf("value: NULL element %d in interval array for field %s", i, v.fd.FullName())
+		}
+
+		d, err := intervalToDuration(iv)
+		if err != nil {
+			return err
+		}
+
+		pl.Append(pr.ValueOfMessage(d.ProtoReflect()))
+	}
+
+	msg.Set(v.fd, pr.ValueOfList(pl))
+	return nil
+}
+
+func (v *durationListValue) SetFrom(msg pr.Message) error {
+	pl := msg.Get(v.fd).List()
+	durations := make([]*durationpb.Duration, pl.Len())
+
+	for i := range durations {
+		durations[i] = concreteMessage(pl.Get(i).Message()).(*durationpb.Duration)
+	}
+
+	return v.Set(durations)
+}
+
+func newDurationValue(fd pr.FieldDescriptor, status pgtype.Status, _ uint32) (Value, error) {
+	if fd.IsList() {
+		v := &durationListValue{newIntervalArray(), fd}
+
+		switch status {
+		case pgtype.Null:
+			v.Set(nil)
+		case pgtype.Present:
+			v.Set([]*durationpb.Duration{})
+		}
+
+		return v, nil
+	}
+
+	return &durationValue{pgtype.Interval{Status: status}, fd}, nil
+}
+
+const (
+	SupportedDuration = "google.protobuf.Duration"
+)
+
+func init() {
+	RegisterMessage(SupportedDuration, newDurationValue)
+}
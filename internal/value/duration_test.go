@@ -0,0 +1,168 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func Test_intervalToDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		iv      pgtype.Interval
+		want    *durationpb.Duration
+		wantErr bool
+	}{
+		{"zero", pgtype.Interval{}, &durationpb.Duration{}, false},
+		{"positive", pgtype.Interval{Microseconds: 1500001}, &durationpb.Duration{Seconds: 1, Nanos: 500001000}, false},
+		{"negative", pgtype.Interval{Microseconds: -1500001}, &durationpb.Duration{Seconds: -1, Nanos: -500001000}, false},
+		{"days", pgtype.Interval{Days: 1}, nil, true},
+		{"months", pgtype.Interval{Months: 1}, nil, true},
+		{"out of range", pgtype.Interval{Microseconds: 315576000001 * microsecondsPerSecond}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := intervalToDuration(tt.iv)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("intervalToDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !proto.Equal(got, tt.want) {
+				t.Errorf("intervalToDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_durationToInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       *durationpb.Duration
+		want    pgtype.Interval
+		wantErr bool
+	}{
+		{"positive", &durationpb.Duration{Seconds: 1, Nanos: 500001999}, pgtype.Interval{Microseconds: 1500001, Status: pgtype.Present}, false},
+		{"negative", &durationpb.Duration{Seconds: -1, Nanos: -500001999}, pgtype.Interval{Microseconds: -1500001, Status: pgtype.Present}, false},
+		{"invalid", &durationpb.Duration{Seconds: 1, Nanos: -1}, pgtype.Interval{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := durationToInterval(tt.d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("durationToInterval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("durationToInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_durationValue(t *testing.T) {
+	want := &support.Supported{Du: durationpb.New(90 * time.Second)}
+	fd := want.ProtoReflect().Descriptor().Fields().ByName("du")
+
+	v, err := newDurationValue(fd, pgtype.Null, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := new(support.Supported)
+	if err = v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+	if got.Du != nil {
+		t.Errorf("durationValue.SetTo = %v, want nil", got.Du)
+	}
+
+	if err = v.SetFrom(want.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+	if iv := v.(*durationValue).Interval; iv.Microseconds != 90*microsecondsPerSecond {
+		t.Errorf("durationValue.SetFrom = %v, want %d microseconds", iv, 90*microsecondsPerSecond)
+	}
+
+	if err = v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("durationValue.SetTo =\n%v\nwant\n%v", got, want)
+	}
+
+	v.(*durationValue).Days = 1
+	if err = v.SetTo(got.ProtoReflect()); err == nil {
+		t.Error("durationValue.SetTo: expected error, got nil")
+	}
+}
+
+func Test_durationListValue(t *testing.T) {
+	want := &support.Supported{RDu: []*durationpb.Duration{
+		durationpb.New(time.Second),
+		durationpb.New(-time.Minute),
+	}}
+	fd := want.ProtoReflect().Descriptor().Fields().ByName("r_du")
+
+	for _, status := range []pgtype.Status{pgtype.Null, pgtype.Present} {
+		v, err := newDurationValue(fd, status, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := new(support.Supported)
+		if err = v.SetTo(got.ProtoReflect()); err != nil {
+			t.Fatal(err)
+		}
+		if got.RDu != nil {
+			t.Errorf("durationListValue.SetTo = %v, want nil", got.RDu)
+		}
+
+		if err = v.SetFrom(want.ProtoReflect()); err != nil {
+			t.Fatal(err)
+		}
+		if err = v.SetTo(got.ProtoReflect()); err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("durationListValue.SetTo =\n%v\nwant\n%v", got, want)
+		}
+	}
+
+	v, err := newDurationValue(fd, pgtype.Undefined, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, src := range []interface{}{
+		[]pgtype.Interval{{Months: 1, Status: pgtype.Present}},
+		[]interface{}{nil},
+	} {
+		if err = v.Set(src); err != nil {
+			t.Fatal(err)
+		}
+		if err = v.SetTo(new(support.Supported).ProtoReflect()); err == nil {
+			t.Errorf("durationListValue.SetTo(%v): expected error, got nil", src)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageElement is a pgtype value, which converts from and to messages of a well-known type.
+// It is used directly for message fields and as array element for repeated message fields.
+//
+// Get must return the messageElement itself when the value is present,
+// so that the elements of a pgtype.ArrayType can be obtained.
+// Set must accept a message of the well-known type.
+type messageElement interface {
+	pgtype.ValueTranscoder
+
+	// toMessage returns the message for the present value.
+	toMessage() (proto.Message, error)
+}
+
+// elementGet implements Get for messageElement types.
+func elementGet(e messageElement, status pgtype.Status) interface{} {
+	switch status {
+	case pgtype.Present:
+		return e
+	case pgtype.Null:
+		return nil
+	default:
+		return status
+	}
+}
+
+// elementValue scans and writes a message field, using a messageElement.
+type elementValue struct {
+	messageElement
+	fd     pr.FieldDescriptor
+	format int16
+}
+
+func (v *elementValue) PGValue() pgtype.Value { return v.messageElement }
+
+func (v *elementValue) PreferredParamFormat() int16 { return v.format }
+
+func (v *elementValue) SetTo(msg pr.Message) error {
+	if _, ok := v.Get().(messageElement); !ok {
+		return nil
+	}
+
+	m, err := v.toMessage()
+	if err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+
+	msg.Set(v.fd, pr.ValueOfMessage(m.ProtoReflect()))
+	return nil
+}
+
+func (v *elementValue) SetFrom(msg pr.Message) error {
+	if err := v.Set(concreteMessage(msg.Get(v.fd).Message())); err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+
+	return nil
+}
+
+// elementListValue scans and writes a repeated message field,
+// from and to an array of messageElement.
+type elementListValue struct {
+	*pgtype.ArrayType
+	fd     pr.FieldDescriptor
+	format int16
+}
+
+func (v *elementListValue) PGValue() pgtype.Value { return v.ArrayType }
+
+func (v *elementListValue) PreferredParamFormat() int16 { return v.format }
+
+func (v *elementListValue) SetTo(msg pr.Message) error {
+	elements, ok := v.Get().([]interface{})
+	if !ok {
+		return nil
+	}
+
+	pl := msg.NewField(v.fd).List()
+
+	for i, elem := range elements {
+		e, ok := elem.(messageElement)
+		if !ok {
+			return fmt.Errorf("value: NULL element %d in array for field %s", i, v.fd.FullName())
+		}
+
+		m, err := e.toMessage()
+		if err != nil {
+			return fmt.Errorf("value: field %s, element %d: %w", v.fd.FullName(), i, err)
+		}
+
+		pl.Append(pr.ValueOfMessage(m.ProtoReflect()))
+	}
+
+	msg.Set(v.fd, pr.ValueOfList(pl))
+	return nil
+}
+
+func (v *elementListValue) SetFrom(msg pr.Message) error {
+	pl := msg.Get(v.fd).List()
+	messages := make([]proto.Message, pl.Len())
+
+	for i := range messages {
+		messages[i] = concreteMessage(pl.Get(i).Message())
+	}
+
+	if err := v.Set(messages); err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+
+	return nil
+}
+
+// elementType describes the PostgreSQL type of a messageElement.
+type elementType struct {
+	name string // Type name, used for the array type name.
+	oid  uint32 // Type OID, or 0 for types without a fixed OID, such as composite types.
+
+	// new returns a messageElement with status.
+	new func(status pgtype.Status) messageElement
+}
+
+// constructor returns a Constructor for message fields of the elementType.
+// Types without a fixed OID are sent in text format,
+// as the binary formats of arrays and composite types contain the type OIDs,
+// which need to match the column's type exactly.
+func (t elementType) constructor() Constructor {
+	format := int16(pgtype.BinaryFormatCode)
+	if t.oid == 0 {
+		format = pgtype.TextFormatCode
+	}
+
+	return func(fd pr.FieldDescriptor, status pgtype.Status, _ uint32) (Value, error) {
+		if !fd.IsList() {
+			return &elementValue{t.new(status), fd, format}, nil
+		}
+
+		v := &elementListValue{
+			ArrayType: pgtype.NewArrayType("_"+t.name, t.oid, func() pgtype.ValueTranscoder {
+				return t.new(pgtype.Undefined)
+			}),
+			fd:     fd,
+			format: format,
+		}
+
+		switch status {
+		case pgtype.Null:
+			v.Set(nil)
+		case pgtype.Present:
+			v.Set([]proto.Message{})
+		}
+
+		return v, nil
+	}
+}
@@ -0,0 +1,246 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func enumByName(fd pr.FieldDescriptor, name string) (pr.Value, error) {
+	ev := fd.Enum().Values().ByName(pr.Name(name))
+	if ev == nil {
+		return pr.Value{}, fmt.Errorf("value: unknown name %q for enum %s", name, fd.Enum().FullName())
+	}
+
+	return pr.ValueOfEnum(ev.Number()), nil
+}
+
+func enumName(fd pr.FieldDescriptor, n pr.EnumNumber) (string, error) {
+	ev := fd.Enum().Values().ByNumber(n)
+	if ev == nil {
+		return "", fmt.Errorf("value: unknown number %d for enum %s", n, fd.Enum().FullName())
+	}
+
+	return string(ev.Name()), nil
+}
+
+// enumNumberValue scans and writes enum numbers from and to integer columns.
+type enumNumberValue struct {
+	pgtype.ValueTranscoder
+	fd pr.FieldDescriptor
+}
+
+func (v *enumNumberValue) PGValue() pgtype.Value { return v.ValueTranscoder }
+
+func (v *enumNumberValue) SetTo(msg pr.Message) error {
+	if v.Get() == nil {
+		return nil
+	}
+
+	var n int32
+	if err := v.AssignTo(&n); err != nil {
+		return err
+	}
+
+	msg.Set(v.fd, pr.ValueOfEnum(pr.EnumNumber(n)))
+	return nil
+}
+
+func (v *enumNumberValue) SetFrom(msg pr.Message) error {
+	return v.Set(int32(msg.Get(v.fd).Enum()))
+}
+
+// enumNameValue scans and writes enum value names from and to text or ENUM columns.
+type enumNameValue struct {
+	pgtype.Text
+	fd pr.FieldDescriptor
+}
+
+func (v *enumNameValue) PGValue() pgtype.Value { return &v.Text }
+
+func (v *enumNameValue) SetTo(msg pr.Message) error {
+	if v.Status != pgtype.Present {
+		return nil
+	}
+
+	ev, err := enumByName(v.fd, v.String)
+	if err != nil {
+		return err
+	}
+
+	msg.Set(v.fd, ev)
+	return nil
+}
+
+func (v *enumNameValue) SetFrom(msg pr.Message) error {
+	name, err := enumName(v.fd, msg.Get(v.fd).Enum())
+	if err != nil {
+		return err
+	}
+
+	return v.Set(name)
+}
+
+// enumNumberListValue scans and writes repeated enum numbers from and to integer array columns.
+type enumNumberListValue struct {
+	pgtype.ValueTranscoder
+	fd pr.FieldDescriptor
+}
+
+func (v *enumNumberListValue) PGValue() pgtype.Value { return v.ValueTranscoder }
+
+func (v *enumNumberListValue) SetTo(msg pr.Message) error {
+	if v.Get() == nil {
+		return nil
+	}
+
+	var list []int32
+	if err := v.AssignTo(&list); err != nil {
+		return err
+	}
+
+	pl := msg.NewField(v.fd).List()
+	for _, n := range list {
+		pl.Append(pr.ValueOfEnum(pr.EnumNumber(n)))
+	}
+
+	msg.Set(v.fd, pr.ValueOfList(pl))
+	return nil
+}
+
+func (v *enumNumberListValue) SetFrom(msg pr.Message) error {
+	pl := msg.Get(v.fd).List()
+	list := make([]int32, pl.Len())
+
+	for i := range list {
+		list[i] = int32(pl.Get(i).Enum())
+	}
+
+	return v.Set(list)
+}
+
+// enumNameListValue scans and writes repeated enum value names
+// from and to text or ENUM array columns.
+type enumNameListValue struct {
+	pgtype.TextArray
+	fd pr.FieldDescriptor
+}
+
+func (v *enumNameListValue) PGValue() pgtype.Value { return &v.TextArray }
+
+// PreferredParamFormat returns the text format code.
+// The binary array format contains the element type,
+// which will not match with the type of an ENUM array column.
+func (v *enumNameListValue) PreferredParamFormat() int16 { return pgtype.TextFormatCode }
+
+func (v *enumNameListValue) SetTo(msg pr.Message) error {
+	if v.Status != pgtype.Present {
+		return nil
+	}
+
+	pl := msg.NewField(v.fd).List()
+	for _, elem := range v.Elements {
+		ev, err := enumByName(v.fd, elem.String)
+		if err != nil {
+			return err
+		}
+
+		pl.Append(ev)
+	}
+
+	msg.Set(v.fd, pr.ValueOfList(pl))
+	return nil
+}
+
+func (v *enumNameListValue) SetFrom(msg pr.Message) error {
+	pl := msg.Get(v.fd).List()
+	names := make([]string, pl.Len())
+
+	for i := range names {
+		name, err := enumName(v.fd, pl.Get(i).Enum())
+		if err != nil {
+			return err
+		}
+
+		names[i] = name
+	}
+
+	return v.Set(names)
+}
+
+// enumEncoding returns the encoding for a column of type oid.
+// Integer columns use EnumNumber. Other known types, such as text,
+// and unknown types, such as PostgreSQL ENUM, use EnumName.
+// If oid is 0 the default EnumNumber is returned.
+func enumEncoding(oid uint32) Encoding {
+	switch oid {
+	case 0,
+		pgtype.Int2OID, pgtype.Int4OID, pgtype.Int8OID,
+		pgtype.Int2ArrayOID, pgtype.Int4ArrayOID, pgtype.Int8ArrayOID:
+		return EnumNumber
+	default:
+		return EnumName
+	}
+}
+
+func newEnumValue(fd pr.FieldDescriptor, status pgtype.Status, enc Encoding, oid uint32) (Value, error) {
+	if enc == Auto {
+		enc = enumEncoding(oid)
+	}
+
+	switch enc {
+	case EnumNumber:
+		if fd.IsList() {
+			var vt pgtype.ValueTranscoder
+			switch oid {
+			case pgtype.Int2ArrayOID:
+				vt = &pgtype.Int2Array{Status: status}
+			case pgtype.Int8ArrayOID:
+				vt = &pgtype.Int8Array{Status: status}
+			default:
+				vt = &pgtype.Int4Array{Status: status}
+			}
+			return &enumNumberListValue{vt, fd}, nil
+		}
+
+		var vt pgtype.ValueTranscoder
+		switch oid {
+		case pgtype.Int2OID:
+			vt = &pgtype.Int2{Status: status}
+		case pgtype.Int8OID:
+			vt = &pgtype.Int8{Status: status}
+		default:
+			vt = &pgtype.Int4{Status: status}
+		}
+		return &enumNumberValue{vt, fd}, nil
+
+	case EnumName:
+		if fd.IsList() {
+			return &enumNameListValue{pgtype.TextArray{Status: status}, fd}, nil
+		}
+		return &enumNameValue{pgtype.Text{Status: status}, fd}, nil
+
+	default:
+		return nil, fmt.Errorf("value: encoding %d not supported for enum field %s", enc, fd.FullName())
+	}
+}
@@ -0,0 +1,254 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_enumNumberValue_SetTo(t *testing.T) {
+	got := &support.Supported{}
+	msg := got.ProtoReflect()
+
+	v := &enumNumberValue{
+		ValueTranscoder: &pgtype.Int2{Status: pgtype.Present, Int: 2},
+		fd:              msg.Descriptor().Fields().ByName("en"),
+	}
+
+	if err := v.SetTo(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &support.Supported{En: support.SimpleColumns_data}
+
+	if !proto.Equal(got, want) {
+		t.Errorf("enumNumberValue.SetTo =\n%v\nwant\n%v ", got, want)
+	}
+}
+
+func Test_enumNumberValue_SetFrom(t *testing.T) {
+	msg := (&support.Supported{En: support.SimpleColumns_created}).ProtoReflect()
+
+	v := &enumNumberValue{
+		ValueTranscoder: &pgtype.Int4{},
+		fd:              msg.Descriptor().Fields().ByName("en"),
+	}
+
+	if err := v.SetFrom(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &pgtype.Int4{Status: pgtype.Present, Int: 3}
+
+	if got := v.PGValue(); !reflect.DeepEqual(got, want) {
+		t.Errorf("enumNumberValue.SetFrom =\n%v\nwant\n%v ", got, want)
+	}
+}
+
+func Test_enumNameValue_SetTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    pgtype.Text
+		want    *support.Supported
+		wantErr bool
+	}{
+		{
+			"null",
+			pgtype.Text{Status: pgtype.Null},
+			&support.Supported{},
+			false,
+		},
+		{
+			"present",
+			pgtype.Text{Status: pgtype.Present, String: "title"},
+			&support.Supported{En: support.SimpleColumns_title},
+			false,
+		},
+		{
+			"unknown name",
+			pgtype.Text{Status: pgtype.Present, String: "foo"},
+			&support.Supported{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &support.Supported{}
+			msg := got.ProtoReflect()
+
+			v := &enumNameValue{
+				Text: tt.text,
+				fd:   msg.Descriptor().Fields().ByName("en"),
+			}
+
+			if err := v.SetTo(msg); (err != nil) != tt.wantErr {
+				t.Errorf("enumNameValue.SetTo error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("enumNameValue.SetTo =\n%v\nwant\n%v ", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_enumNameValue_SetFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     *support.Supported
+		want    pgtype.Value
+		wantErr bool
+	}{
+		{
+			"known",
+			&support.Supported{En: support.SimpleColumns_data},
+			&pgtype.Text{Status: pgtype.Present, String: "data"},
+			false,
+		},
+		{
+			"unknown number",
+			&support.Supported{En: 99},
+			&pgtype.Text{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := tt.msg.ProtoReflect()
+
+			v := &enumNameValue{
+				fd: msg.Descriptor().Fields().ByName("en"),
+			}
+
+			if err := v.SetFrom(msg); (err != nil) != tt.wantErr {
+				t.Errorf("enumNameValue.SetFrom error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := v.PGValue(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("enumNameValue.SetFrom =\n%v\nwant\n%v ", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_enumNumberListValue(t *testing.T) {
+	msg := (&support.Supported{
+		REn: []support.SimpleColumns{support.SimpleColumns_title, support.SimpleColumns_created},
+	}).ProtoReflect()
+	fd := msg.Descriptor().Fields().ByName("r_en")
+
+	v := &enumNumberListValue{&pgtype.Int8Array{}, fd}
+	if err := v.SetFrom(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &support.Supported{}
+	if err := v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(got, msg.Interface()) {
+		t.Errorf("enumNumberListValue =\n%v\nwant\n%v ", got, msg.Interface())
+	}
+}
+
+func Test_enumNameListValue(t *testing.T) {
+	msg := (&support.Supported{
+		REn: []support.SimpleColumns{support.SimpleColumns_title, support.SimpleColumns_created},
+	}).ProtoReflect()
+	fd := msg.Descriptor().Fields().ByName("r_en")
+
+	v := &enumNameListValue{fd: fd}
+	if err := v.SetFrom(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	if err := v.AssignTo(&names); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"title", "created"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("enumNameListValue.SetFrom = %v, want %v", names, want)
+	}
+
+	got := &support.Supported{}
+	if err := v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(got, msg.Interface()) {
+		t.Errorf("enumNameListValue.SetTo =\n%v\nwant\n%v ", got, msg.Interface())
+	}
+
+	v.Elements[0].String = "foo"
+	if err := v.SetTo(got.ProtoReflect()); err == nil {
+		t.Error("enumNameListValue.SetTo: expected error, got nil")
+	}
+}
+
+func Test_newEnumValue(t *testing.T) {
+	fields := new(support.Supported).ProtoReflect().Descriptor().Fields()
+	en, rEn := fields.ByName("en"), fields.ByName("r_en")
+
+	tests := []struct {
+		name    string
+		enc     Encoding
+		oid     uint32
+		list    bool
+		want    Value
+		wantErr bool
+	}{
+		{"auto, unknown oid", Auto, 0, false, &enumNumberValue{&pgtype.Int4{}, en}, false},
+		{"auto, smallint", Auto, pgtype.Int2OID, false, &enumNumberValue{&pgtype.Int2{}, en}, false},
+		{"auto, bigint", Auto, pgtype.Int8OID, false, &enumNumberValue{&pgtype.Int8{}, en}, false},
+		{"auto, text", Auto, pgtype.TextOID, false, &enumNameValue{pgtype.Text{}, en}, false},
+		{"auto, enum type", Auto, 99999, false, &enumNameValue{pgtype.Text{}, en}, false},
+		{"number", EnumNumber, pgtype.TextOID, false, &enumNumberValue{&pgtype.Int4{}, en}, false},
+		{"name", EnumName, 0, false, &enumNameValue{pgtype.Text{}, en}, false},
+		{"auto, list", Auto, pgtype.Int4ArrayOID, true, &enumNumberListValue{&pgtype.Int4Array{}, rEn}, false},
+		{"auto, smallint list", Auto, pgtype.Int2ArrayOID, true, &enumNumberListValue{&pgtype.Int2Array{}, rEn}, false},
+		{"auto, bigint list", Auto, pgtype.Int8ArrayOID, true, &enumNumberListValue{&pgtype.Int8Array{}, rEn}, false},
+		{"auto, text list", Auto, pgtype.TextArrayOID, true, &enumNameListValue{pgtype.TextArray{}, rEn}, false},
+		{"name, list", EnumName, 0, true, &enumNameListValue{pgtype.TextArray{}, rEn}, false},
+		{"unsupported encoding", 99, 0, false, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := en
+			if tt.list {
+				fd = rEn
+			}
+
+			got, err := newEnumValue(fd, pgtype.Undefined, tt.enc, tt.oid)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newEnumValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newEnumValue() =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
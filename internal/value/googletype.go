@@ -0,0 +1,353 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/decimal"
+	"google.golang.org/genproto/googleapis/type/latlng"
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/genproto/googleapis/type/timeofday"
+	"google.golang.org/protobuf/proto"
+)
+
+// dateElement converts google.type.Date messages from and to date columns.
+// Partial dates, with a zero year, month or day, are not supported.
+type dateElement struct {
+	pgtype.Date
+}
+
+func (e *dateElement) Get() interface{} { return elementGet(e, e.Status) }
+
+func (e *dateElement) Set(src interface{}) error {
+	d, ok := src.(*date.Date)
+	if !ok {
+		return e.Date.Set(src)
+	}
+
+	if d.GetYear() == 0 || d.GetMonth() == 0 || d.GetDay() == 0 {
+		return fmt.Errorf("partial date %v not supported", d)
+	}
+
+	t := time.Date(int(d.GetYear()), time.Month(d.GetMonth()), int(d.GetDay()), 0, 0, 0, 0, time.UTC)
+	if t.Year() != int(d.GetYear()) || t.Month() != time.Month(d.GetMonth()) || t.Day() != int(d.GetDay()) {
+		return fmt.Errorf("invalid date %v", d)
+	}
+
+	e.Date = pgtype.Date{Time: t, Status: pgtype.Present}
+	return nil
+}
+
+func (e *dateElement) toMessage() (proto.Message, error) {
+	if e.InfinityModifier != pgtype.None {
+		return nil, errors.New("infinite date not supported")
+	}
+
+	year, month, day := e.Time.Date()
+	if year < 1 || year > 9999 {
+		return nil, fmt.Errorf("year %d out of range", year)
+	}
+
+	return &date.Date{Year: int32(year), Month: int32(month), Day: int32(day)}, nil
+}
+
+const microsecondsPerDay = 24 * 60 * 60 * microsecondsPerSecond
+
+// timeOfDayElement converts google.type.TimeOfDay messages from and to time columns.
+type timeOfDayElement struct {
+	pgtype.Time
+}
+
+func (e *timeOfDayElement) Get() interface{} { return elementGet(e, e.Status) }
+
+func (e *timeOfDayElement) Set(src interface{}) error {
+	t, ok := src.(*timeofday.TimeOfDay)
+	if !ok {
+		return e.Time.Set(src)
+	}
+
+	h, m, s, n := t.GetHours(), t.GetMinutes(), t.GetSeconds(), t.GetNanos()
+	if h < 0 || h > 24 || m < 0 || m > 59 || s < 0 || s > 60 || n < 0 || n > 999999999 ||
+		h == 24 && (m != 0 || s != 0 || n != 0) {
+		return fmt.Errorf("invalid time of day %v", t)
+	}
+
+	e.Time = pgtype.Time{
+		Microseconds: ((int64(h)*60+int64(m))*60+int64(s))*microsecondsPerSecond + int64(n/1000),
+		Status:       pgtype.Present,
+	}
+	return nil
+}
+
+func (e *timeOfDayElement) toMessage() (proto.Message, error) {
+	us := e.Microseconds
+	if us < 0 || us > microsecondsPerDay {
+		return nil, fmt.Errorf("time of %d microseconds out of range", us)
+	}
+
+	seconds := us / microsecondsPerSecond
+
+	return &timeofday.TimeOfDay{
+		Hours:   int32(seconds / 3600),
+		Minutes: int32(seconds / 60 % 60),
+		Seconds: int32(seconds % 60),
+		Nanos:   int32(us%microsecondsPerSecond) * 1000,
+	}, nil
+}
+
+// latLngElement converts google.type.LatLng messages from and to point columns.
+// The longitude is stored as X and the latitude as Y, like PostGIS does.
+type latLngElement struct {
+	pgtype.Point
+}
+
+func (e *latLngElement) Get() interface{} { return elementGet(e, e.Status) }
+
+func (e *latLngElement) Set(src interface{}) error {
+	ll, ok := src.(*latlng.LatLng)
+	if !ok {
+		return e.Point.Set(src)
+	}
+
+	e.Point = pgtype.Point{
+		P:      pgtype.Vec2{X: ll.GetLongitude(), Y: ll.GetLatitude()},
+		Status: pgtype.Present,
+	}
+	return nil
+}
+
+func (e *latLngElement) toMessage() (proto.Message, error) {
+	return &latlng.LatLng{Latitude: e.P.Y, Longitude: e.P.X}, nil
+}
+
+// decimalElement converts google.type.Decimal messages from and to numeric columns.
+type decimalElement struct {
+	pgtype.Numeric
+}
+
+func (e *decimalElement) Get() interface{} { return elementGet(e, e.Status) }
+
+func (e *decimalElement) Set(src interface{}) error {
+	d, ok := src.(*decimal.Decimal)
+	if !ok {
+		return e.Numeric.Set(src)
+	}
+
+	n, exp, err := parseDecimal(d.GetValue())
+	if err != nil {
+		return err
+	}
+
+	e.Numeric = pgtype.Numeric{Int: n, Exp: exp, Status: pgtype.Present}
+	return nil
+}
+
+// EncodeText encodes the numeric without exponent, see numericText.
+func (e decimalElement) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	if e.Status != pgtype.Present {
+		return e.Numeric.EncodeText(ci, buf)
+	}
+
+	return append(buf, numericText(e.Numeric)...), nil
+}
+
+func (e *decimalElement) toMessage() (proto.Message, error) {
+	if e.NaN {
+		return nil, errors.New("NaN not supported")
+	}
+
+	return &decimal.Decimal{Value: formatDecimal(numericInt(e.Numeric), e.Exp)}, nil
+}
+
+// numericInt returns the unscaled integer of n, which is nil for zero values.
+func numericInt(n pgtype.Numeric) *big.Int {
+	if n.Int == nil {
+		return new(big.Int)
+	}
+
+	return n.Int
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+const nanosPerUnit = 1000000000
+
+// moneyElement converts google.type.Money messages from and to columns of any composite type,
+// with a text currency code and a numeric amount as attributes. For example:
+//
+//	create type money_amount as (currency_code text, amount numeric);
+//
+// The names of the type and its attributes are not used.
+// The attributes are written in text format and scanned from text in the order above.
+// When scanned in binary format, such as for types registered in the pgtype.ConnInfo,
+// the amount is resolved by its numeric type, allowing any order of the attributes.
+//
+// This is the only supported mapping of Money. A separate currency column is not supported.
+type moneyElement struct {
+	*pgtype.CompositeType
+	currency pgtype.Text
+	amount   decimalElement
+}
+
+func newMoneyElement(status pgtype.Status) messageElement {
+	e := new(moneyElement)
+	e.CompositeType = e.composite(false)
+
+	switch status {
+	case pgtype.Null:
+		e.Set(nil)
+	case pgtype.Present:
+		e.Set(&money.Money{})
+	}
+
+	return e
+}
+
+// composite returns a CompositeType of the currency code and amount,
+// or of the amount and currency code when amountFirst is set.
+func (e *moneyElement) composite(amountFirst bool) *pgtype.CompositeType {
+	fields := []pgtype.CompositeTypeField{
+		{Name: "currency_code", OID: pgtype.TextOID},
+		{Name: "amount", OID: pgtype.NumericOID},
+	}
+	values := []pgtype.ValueTranscoder{&e.currency, &e.amount}
+
+	if amountFirst {
+		fields[0], fields[1] = fields[1], fields[0]
+		values[0], values[1] = values[1], values[0]
+	}
+
+	ct, _ := pgtype.NewCompositeTypeValues("record", fields, values)
+	return ct
+}
+
+// DecodeBinary resolves the order of the attributes by their type OIDs, before decoding.
+func (e *moneyElement) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	if src != nil {
+		var oids []uint32
+
+		scanner := pgtype.NewCompositeBinaryScanner(ci, src)
+		for scanner.Next() {
+			oids = append(oids, scanner.OID())
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+
+		if len(oids) != 2 || (oids[0] == pgtype.NumericOID) == (oids[1] == pgtype.NumericOID) {
+			return fmt.Errorf("value: composite with attribute types %v is not a currency code and numeric amount", oids)
+		}
+
+		e.CompositeType = e.composite(oids[0] == pgtype.NumericOID)
+	}
+
+	return e.CompositeType.DecodeBinary(ci, src)
+}
+
+func (e *moneyElement) Get() interface{} {
+	switch v := e.CompositeType.Get().(type) {
+	case nil, pgtype.Status:
+		return v
+	default:
+		return e
+	}
+}
+
+func (e *moneyElement) Set(src interface{}) error {
+	m, ok := src.(*money.Money)
+	if !ok {
+		return e.CompositeType.Set(src)
+	}
+
+	units, nanos := m.GetUnits(), m.GetNanos()
+	if nanos <= -nanosPerUnit || nanos >= nanosPerUnit ||
+		units > 0 && nanos < 0 || units < 0 && nanos > 0 {
+		return fmt.Errorf("invalid money %v", m)
+	}
+
+	amount := new(big.Int).Mul(big.NewInt(units), big.NewInt(nanosPerUnit))
+	amount.Add(amount, big.NewInt(int64(nanos)))
+
+	return e.CompositeType.Set([]interface{}{m.GetCurrencyCode(), formatDecimal(amount, -9)})
+}
+
+func (e *moneyElement) toMessage() (proto.Message, error) {
+	if e.amount.NaN {
+		return nil, errors.New("NaN not supported")
+	}
+
+	// Scale the amount to nanos.
+	amount := new(big.Int).Set(numericInt(e.amount.Numeric))
+	if exp := e.amount.Exp + 9; exp >= 0 {
+		amount.Mul(amount, pow10(exp))
+	} else {
+		var rem big.Int
+		if amount.QuoRem(amount, pow10(-exp), &rem); rem.Sign() != 0 {
+			return nil, fmt.Errorf("amount %s has more than 9 decimals", formatDecimal(numericInt(e.amount.Numeric), e.amount.Exp))
+		}
+	}
+
+	units, nanos := amount.QuoRem(amount, big.NewInt(nanosPerUnit), new(big.Int))
+	if !units.IsInt64() {
+		return nil, fmt.Errorf("amount %s out of range", formatDecimal(numericInt(e.amount.Numeric), e.amount.Exp))
+	}
+
+	return &money.Money{
+		CurrencyCode: e.currency.String,
+		Units:        units.Int64(),
+		Nanos:        int32(nanos.Int64()),
+	}, nil
+}
+
+const (
+	SupportedDate      = "google.type.Date"
+	SupportedTimeOfDay = "google.type.TimeOfDay"
+	SupportedLatLng    = "google.type.LatLng"
+	SupportedDecimal   = "google.type.Decimal"
+	SupportedMoney     = "google.type.Money"
+)
+
+func init() {
+	RegisterMessage(SupportedDate, elementType{"date", pgtype.DateOID, func(status pgtype.Status) messageElement {
+		return &dateElement{pgtype.Date{Status: status}}
+	}}.constructor())
+
+	RegisterMessage(SupportedTimeOfDay, elementType{"time", pgtype.TimeOID, func(status pgtype.Status) messageElement {
+		return &timeOfDayElement{pgtype.Time{Status: status}}
+	}}.constructor())
+
+	RegisterMessage(SupportedLatLng, elementType{"point", pgtype.PointOID, func(status pgtype.Status) messageElement {
+		return &latLngElement{pgtype.Point{Status: status}}
+	}}.constructor())
+
+	RegisterMessage(SupportedDecimal, elementType{"numeric", pgtype.NumericOID, func(status pgtype.Status) messageElement {
+		return &decimalElement{pgtype.Numeric{Int: new(big.Int), Status: status}}
+	}}.constructor())
+
+	RegisterMessage(SupportedMoney, elementType{"record", 0, newMoneyElement}.constructor())
+}
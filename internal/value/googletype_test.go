@@ -0,0 +1,281 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/decimal"
+	"google.golang.org/genproto/googleapis/type/latlng"
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/genproto/googleapis/type/timeofday"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func Test_googleTypes(t *testing.T) {
+	ci := pgtype.NewConnInfo()
+
+	src := &support.Supported{
+		Dt:   &date.Date{Year: 2022, Month: 2, Day: 28},
+		Tod:  &timeofday.TimeOfDay{Hours: 13, Minutes: 14, Seconds: 15, Nanos: 16000},
+		Ll:   &latlng.LatLng{Latitude: 52.1, Longitude: 4.3},
+		Dec:  &decimal.Decimal{Value: "-12.340"},
+		Mon:  &money.Money{CurrencyCode: "EUR", Units: -1, Nanos: -750000000},
+		RDt:  []*date.Date{{Year: 1, Month: 1, Day: 1}, {Year: 9999, Month: 12, Day: 31}},
+		RTod: []*timeofday.TimeOfDay{{}, {Hours: 24}},
+		RLl:  []*latlng.LatLng{{Latitude: -90, Longitude: 180}},
+		RDec: []*decimal.Decimal{{Value: "0.005"}, {Value: "1500"}},
+		RMon: []*money.Money{{CurrencyCode: "USD", Nanos: 1}, {CurrencyCode: "JPY", Units: 100}},
+	}
+
+	formats := map[string]struct {
+		encode func(pgtype.ValueTranscoder) ([]byte, error)
+		decode func(pgtype.ValueTranscoder, []byte) error
+	}{
+		"binary": {
+			func(v pgtype.ValueTranscoder) ([]byte, error) { return v.EncodeBinary(ci, nil) },
+			func(v pgtype.ValueTranscoder, buf []byte) error { return v.DecodeBinary(ci, buf) },
+		},
+		"text": {
+			func(v pgtype.ValueTranscoder) ([]byte, error) { return v.EncodeText(ci, nil) },
+			func(v pgtype.ValueTranscoder, buf []byte) error { return v.DecodeText(ci, buf) },
+		},
+	}
+
+	for _, field := range []string{"dt", "tod", "ll", "dec", "mon", "r_dt", "r_tod", "r_ll", "r_dec", "r_mon"} {
+		fd := src.ProtoReflect().Descriptor().Fields().ByName(pr.Name(field))
+
+		want := new(support.Supported)
+		want.ProtoReflect().Set(fd, src.ProtoReflect().Get(fd))
+
+		for name, format := range formats {
+			t.Run(field+" "+name, func(t *testing.T) {
+				arg, err := New(fd, pgtype.Null)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err = arg.SetFrom(want.ProtoReflect()); err != nil {
+					t.Fatal(err)
+				}
+
+				buf, err := format.encode(arg)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				dst, err := New(fd, pgtype.Undefined)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err = format.decode(dst, buf); err != nil {
+					t.Fatal(err)
+				}
+
+				got := new(support.Supported)
+				if err = dst.SetTo(got.ProtoReflect()); err != nil {
+					t.Fatal(err)
+				}
+				if !proto.Equal(got, want) {
+					t.Errorf("SetTo =\n%v\nwant\n%v", got, want)
+				}
+			})
+		}
+
+		t.Run(field+" null", func(t *testing.T) {
+			v, err := New(fd, pgtype.Null)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(support.Supported)
+			if err = v.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if got.ProtoReflect().Has(fd) {
+				t.Errorf("SetTo = %v, want unset", got)
+			}
+		})
+	}
+}
+
+func Test_googleTypes_setFrom_error(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *support.Supported
+	}{
+		{"dt", &support.Supported{Dt: &date.Date{Year: 2022, Month: 2}}},
+		{"dt", &support.Supported{Dt: &date.Date{Year: 2022, Month: 2, Day: 30}}},
+		{"tod", &support.Supported{Tod: &timeofday.TimeOfDay{Hours: 24, Minutes: 1}}},
+		{"tod", &support.Supported{Tod: &timeofday.TimeOfDay{Nanos: -1}}},
+		{"dec", &support.Supported{Dec: &decimal.Decimal{}}},
+		{"dec", &support.Supported{Dec: &decimal.Decimal{Value: "1,5"}}},
+		{"mon", &support.Supported{Mon: &money.Money{Units: 1, Nanos: -1}}},
+		{"mon", &support.Supported{Mon: &money.Money{Nanos: nanosPerUnit}}},
+		{"r_dec", &support.Supported{RDec: []*decimal.Decimal{{Value: "x"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := tt.msg.ProtoReflect().Descriptor().Fields().ByName(pr.Name(tt.name))
+
+			v, err := New(fd, pgtype.Null)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = v.SetFrom(tt.msg.ProtoReflect()); err == nil {
+				t.Error("SetFrom: expected error, got nil")
+			}
+		})
+	}
+}
+
+func Test_googleTypes_setTo_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"dt", pgtype.Date{Status: pgtype.Present, InfinityModifier: pgtype.Infinity}},
+		{"tod", pgtype.Time{Status: pgtype.Present, Microseconds: microsecondsPerDay + 1}},
+		{"dec", pgtype.Numeric{Status: pgtype.Present, NaN: true}},
+		{"mon", []interface{}{"EUR", "1.0000000001"}},
+		{"mon", []interface{}{"EUR", "100000000000000000000"}},
+		{"mon", []interface{}{"EUR", math.NaN()}},
+		{"r_dec", []interface{}{nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := new(support.Supported).ProtoReflect().Descriptor().Fields().ByName(pr.Name(tt.name))
+
+			v, err := New(fd, pgtype.Undefined)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			switch x := v.(type) {
+			case *elementValue:
+				switch e := x.messageElement.(type) {
+				case *dateElement:
+					e.Date = tt.value.(pgtype.Date)
+				case *timeOfDayElement:
+					e.Time = tt.value.(pgtype.Time)
+				case *decimalElement:
+					e.Numeric = tt.value.(pgtype.Numeric)
+				default:
+					err = v.Set(tt.value)
+				}
+			default:
+				err = v.Set(tt.value)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = v.SetTo(new(support.Supported).ProtoReflect()); err == nil {
+				t.Error("SetTo: expected error, got nil")
+			}
+		})
+	}
+}
+
+func Test_moneyElement_DecodeBinary(t *testing.T) {
+	ci := pgtype.NewConnInfo()
+	currency := &pgtype.Varchar{String: "EUR", Status: pgtype.Present}
+	amount := &pgtype.Numeric{Int: big.NewInt(-175), Exp: -2, Status: pgtype.Present}
+
+	composite := func(oids []uint32, values ...pgtype.BinaryEncoder) []byte {
+		b := pgtype.NewCompositeBinaryBuilder(ci, nil)
+		for i, v := range values {
+			b.AppendEncoder(oids[i], v)
+		}
+
+		buf, err := b.Finish()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return buf
+	}
+
+	tests := []struct {
+		name    string
+		src     []byte
+		want    *money.Money
+		wantErr bool
+	}{
+		{
+			"currency first",
+			composite([]uint32{pgtype.VarcharOID, pgtype.NumericOID}, currency, amount),
+			&money.Money{CurrencyCode: "EUR", Units: -1, Nanos: -750000000},
+			false,
+		},
+		{
+			"amount first",
+			composite([]uint32{pgtype.NumericOID, pgtype.VarcharOID}, amount, currency),
+			&money.Money{CurrencyCode: "EUR", Units: -1, Nanos: -750000000},
+			false,
+		},
+		{
+			"no amount",
+			composite([]uint32{pgtype.VarcharOID, pgtype.VarcharOID}, currency, currency),
+			nil,
+			true,
+		},
+		{
+			"two amounts",
+			composite([]uint32{pgtype.NumericOID, pgtype.NumericOID}, amount, amount),
+			nil,
+			true,
+		},
+		{
+			"three attributes",
+			composite([]uint32{pgtype.VarcharOID, pgtype.NumericOID, pgtype.VarcharOID}, currency, amount, currency),
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := new(support.Supported).ProtoReflect().Descriptor().Fields().ByName("mon")
+
+			v, err := New(fd, pgtype.Undefined)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = v.DecodeBinary(ci, tt.src); (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeBinary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got := new(support.Supported)
+			if err = v.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got.GetMon(), tt.want) {
+				t.Errorf("SetTo = %v, want %v", got.GetMon(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	"google.golang.org/protobuf/encoding/protojson"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// marshalFieldJSON returns the JSON encoding of a single field of msg, using protojson.
+// The field is marshalled as part of a new message of the same type as msg,
+// so that protojson encoding rules apply to any field kind, including maps and lists.
+func marshalFieldJSON(mo protojson.MarshalOptions, msg pr.Message, fd pr.FieldDescriptor) ([]byte, error) {
+	tmp := msg.New()
+	tmp.Set(fd, msg.Get(fd))
+
+	data, err := mo.Marshal(tmp.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("value: field %s: %w", fd.FullName(), err)
+	}
+
+	var obj map[string]json.RawMessage
+	if err = json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	if mo.UseProtoNames {
+		return obj[string(fd.Name())], nil
+	}
+
+	return obj[fd.JSONName()], nil
+}
+
+// unmarshalFieldJSON sets a single field of msg from its JSON encoding, using protojson.
+// See marshalFieldJSON.
+func unmarshalFieldJSON(uo protojson.UnmarshalOptions, msg pr.Message, fd pr.FieldDescriptor, data []byte) error {
+	name := string(fd.Name())
+
+	buf := make([]byte, 0, len(name)+len(data)+5)
+	buf = append(buf, `{"`...)
+	buf = append(buf, name...)
+	buf = append(buf, `":`...)
+	buf = append(buf, data...)
+	buf = append(buf, '}')
+
+	tmp := msg.New()
+	if err := uo.Unmarshal(buf, tmp.Interface()); err != nil {
+		return fmt.Errorf("value: field %s: %w", fd.FullName(), err)
+	}
+
+	if tmp.Has(fd) {
+		msg.Set(fd, tmp.Get(fd))
+	}
+
+	return nil
+}
+
+// jsonValue scans and writes a field from and to a json or jsonb column.
+type jsonValue struct {
+	pgtype.ValueTranscoder
+	json  *pgtype.JSON // Same value as the ValueTranscoder.
+	fd    pr.FieldDescriptor
+	mo    protojson.MarshalOptions
+	uo    protojson.UnmarshalOptions
+	nulls NullElementPolicy // Policy for null elements of repeated message fields.
+}
+
+func (v *jsonValue) PGValue() pgtype.Value { return v.ValueTranscoder }
+
+func (v *jsonValue) SetTo(msg pr.Message) error {
+	if v.json.Status != pgtype.Present {
+		return nil
+	}
+
+	var (
+		data    = v.json.Bytes
+		indices []int
+	)
+	if v.fd.IsList() && v.fd.Message() != nil && v.fd.Message().FullName() != SupportedValue {
+		var err error
+		if data, indices, err = removeJSONNulls(data); err != nil {
+			return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+		}
+	}
+
+	if len(indices) > 0 {
+		switch v.nulls {
+		case NullElementSkip, NullElementZero:
+		default:
+			return fmt.Errorf("value: field %s: null array element %d", v.fd.FullName(), indices[0])
+		}
+	}
+
+	if err := unmarshalFieldJSON(v.uo, msg, v.fd, data); err != nil {
+		return err
+	}
+
+	if v.nulls == NullElementZero && len(indices) > 0 {
+		insertZeroElements(msg, v.fd, indices)
+	}
+
+	return nil
+}
+
+// removeJSONNulls removes null elements from a JSON array and returns their indices.
+// The result of json_agg(...) over an outer join contains null
+// for rows without a match, which can't be represented in a repeated message field.
+// Data which is not an array, such as null, is returned unchanged.
+func removeJSONNulls(data []byte) ([]byte, []int, error) {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil || elems == nil {
+		return data, nil, nil
+	}
+
+	var (
+		n       int
+		indices []int
+	)
+	for i, elem := range elems {
+		if string(elem) == "null" {
+			indices = append(indices, i)
+			continue
+		}
+
+		elems[n] = elem
+		n++
+	}
+
+	if n == len(elems) {
+		return data, nil, nil
+	}
+
+	data, err := json.Marshal(elems[:n])
+	return data, indices, err
+}
+
+func (v *jsonValue) SetFrom(msg pr.Message) error {
+	data, err := marshalFieldJSON(v.mo, msg, v.fd)
+	if err != nil {
+		return err
+	}
+
+	return v.Set(data)
+}
+
+// jsonZero returns the JSON representation of an empty field.
+// Empty messages and maps are represented as an empty object,
+// except for the ListValue and Value types, which are represented as an empty array and null.
+func jsonZero(fd pr.FieldDescriptor) []byte {
+	if fd.IsList() {
+		return []byte("[]")
+	}
+
+	if md := fd.Message(); md != nil && !fd.IsMap() {
+		switch md.FullName() {
+		case SupportedListValue:
+			return []byte("[]")
+		case SupportedValue:
+			return []byte("null")
+		}
+	}
+
+	return []byte("{}")
+}
+
+// newJSONValue returns a value for json columns, when oid is pgtype.JSONOID.
+// Otherwise the value is for jsonb columns.
+func (o *Options) newJSONValue(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) *jsonValue {
+	v := &jsonValue{
+		fd:    fd,
+		mo:    o.JSONMarshal,
+		uo:    o.JSONUnmarshal,
+		nulls: o.NullElements,
+	}
+
+	if oid == pgtype.JSONOID {
+		j := &pgtype.JSON{Status: status}
+		v.ValueTranscoder, v.json = j, j
+	} else {
+		j := &pgtype.JSONB{Status: status}
+		v.ValueTranscoder, v.json = j, (*pgtype.JSON)(j)
+	}
+
+	if status == pgtype.Present {
+		v.json.Bytes = jsonZero(fd)
+	}
+
+	return v
+}
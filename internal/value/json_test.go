@@ -0,0 +1,431 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// jsonEqual compares a and b semantically,
+// as protojson output is not stable.
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+
+	var x, y interface{}
+	if err := json.Unmarshal(a, &x); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		t.Fatal(err)
+	}
+
+	return reflect.DeepEqual(x, y)
+}
+
+func Test_marshalFieldJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		mo    protojson.MarshalOptions
+		msg   proto.Message
+		field string
+		want  string
+	}{
+		{
+			"map",
+			protojson.MarshalOptions{},
+			&support.Supported{Mp: map[string]int32{"foo": 1, "bar": 2}},
+			"mp",
+			`{"foo": 1, "bar": 2}`,
+		},
+		{
+			"timestamp map",
+			protojson.MarshalOptions{},
+			&support.Supported{TsMp: map[int32]*timestamppb.Timestamp{
+				1: {Seconds: 12},
+			}},
+			"ts_mp",
+			`{"1": "1970-01-01T00:00:12Z"}`,
+		},
+		{
+			"list",
+			protojson.MarshalOptions{},
+			&support.Supported{RI64: []int64{1, 2}},
+			"r_i64",
+			`["1", "2"]`,
+		},
+		{
+			"message, json names",
+			protojson.MarshalOptions{},
+			&support.Unsupported{Sup: &support.Supported{RI32: []int32{1}}},
+			"sup",
+			`{"rI32": [1]}`,
+		},
+		{
+			"message, proto names",
+			protojson.MarshalOptions{UseProtoNames: true},
+			&support.Unsupported{Sup: &support.Supported{RI32: []int32{1}}},
+			"sup",
+			`{"r_i32": [1]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := tt.msg.ProtoReflect()
+
+			got, err := marshalFieldJSON(tt.mo, msg, msg.Descriptor().Fields().ByName(pr.Name(tt.field)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !jsonEqual(t, got, []byte(tt.want)) {
+				t.Errorf("marshalFieldJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_unmarshalFieldJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		uo      protojson.UnmarshalOptions
+		field   string
+		data    string
+		want    *support.Supported
+		wantErr bool
+	}{
+		{
+			"map",
+			protojson.UnmarshalOptions{},
+			"mp",
+			`{"foo": 1, "bar": 2}`,
+			&support.Supported{Mp: map[string]int32{"foo": 1, "bar": 2}},
+			false,
+		},
+		{
+			"timestamp map",
+			protojson.UnmarshalOptions{},
+			"ts_mp",
+			`{"1": "1970-01-01T00:00:12Z"}`,
+			&support.Supported{TsMp: map[int32]*timestamppb.Timestamp{
+				1: {Seconds: 12},
+			}},
+			false,
+		},
+		{
+			"null",
+			protojson.UnmarshalOptions{},
+			"mp",
+			`null`,
+			&support.Supported{},
+			false,
+		},
+		{
+			"error",
+			protojson.UnmarshalOptions{},
+			"mp",
+			`{"foo": "bar"}`,
+			&support.Supported{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &support.Supported{}
+			msg := got.ProtoReflect()
+
+			err := unmarshalFieldJSON(tt.uo, msg, msg.Descriptor().Fields().ByName(pr.Name(tt.field)), []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unmarshalFieldJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("unmarshalFieldJSON() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_unmarshalFieldJSON_message(t *testing.T) {
+	tests := []struct {
+		name    string
+		uo      protojson.UnmarshalOptions
+		data    string
+		want    *support.Unsupported
+		wantErr bool
+	}{
+		{
+			"json names",
+			protojson.UnmarshalOptions{},
+			`{"rI32": [1], "ts": "1970-01-01T00:00:12Z"}`,
+			&support.Unsupported{Sup: &support.Supported{
+				RI32: []int32{1},
+				Ts:   &timestamppb.Timestamp{Seconds: 12},
+			}},
+			false,
+		},
+		{
+			"proto names",
+			protojson.UnmarshalOptions{},
+			`{"r_i32": [1]}`,
+			&support.Unsupported{Sup: &support.Supported{RI32: []int32{1}}},
+			false,
+		},
+		{
+			"unknown field error",
+			protojson.UnmarshalOptions{},
+			`{"r_i32": [1], "foo": "bar"}`,
+			&support.Unsupported{},
+			true,
+		},
+		{
+			"discard unknown",
+			protojson.UnmarshalOptions{DiscardUnknown: true},
+			`{"r_i32": [1], "foo": "bar"}`,
+			&support.Unsupported{Sup: &support.Supported{RI32: []int32{1}}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &support.Unsupported{}
+			msg := got.ProtoReflect()
+
+			err := unmarshalFieldJSON(tt.uo, msg, msg.Descriptor().Fields().ByName("sup"), []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unmarshalFieldJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("unmarshalFieldJSON() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_jsonValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		msg   proto.Message
+	}{
+		{
+			"map",
+			"mp",
+			&support.Supported{Mp: map[string]int32{"foo": 1}},
+		},
+		{
+			"message",
+			"sup",
+			&support.Unsupported{Sup: &support.Supported{I32: 1, S: "foo"}},
+		},
+		{
+			"repeated timestamps",
+			"r_ts",
+			&support.Supported{RTs: []*timestamppb.Timestamp{{Seconds: 1}, {Seconds: 2}}},
+		},
+	}
+	for _, tt := range tests {
+		for _, oid := range []uint32{pgtype.JSONOID, pgtype.JSONBOID} {
+			t.Run(tt.name, func(t *testing.T) {
+				want := tt.msg.ProtoReflect()
+				v := new(Options).newJSONValue(want.Descriptor().Fields().ByName(pr.Name(tt.field)), pgtype.Null, oid)
+
+				if err := v.SetFrom(want); err != nil {
+					t.Fatal(err)
+				}
+
+				got := want.New()
+				if err := v.SetTo(got); err != nil {
+					t.Fatal(err)
+				}
+
+				if !proto.Equal(got.Interface(), tt.msg) {
+					t.Errorf("jsonValue =\n%v\nwant\n%v", got, tt.msg)
+				}
+			})
+		}
+	}
+}
+
+func Test_newJSONValue(t *testing.T) {
+	fields := new(support.Supported).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		name   string
+		field  string
+		status pgtype.Status
+		oid    uint32
+		want   pgtype.Value
+	}{
+		{"json", "mp", pgtype.Null, pgtype.JSONOID, &pgtype.JSON{Status: pgtype.Null}},
+		{"jsonb", "mp", pgtype.Null, pgtype.JSONBOID, &pgtype.JSONB{Status: pgtype.Null}},
+		{"unknown", "mp", pgtype.Null, 0, &pgtype.JSONB{Status: pgtype.Null}},
+		{"zero map", "mp", pgtype.Present, 0, &pgtype.JSONB{Status: pgtype.Present, Bytes: []byte("{}")}},
+		{"zero list", "r_i32", pgtype.Present, 0, &pgtype.JSONB{Status: pgtype.Present, Bytes: []byte("[]")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(Options).newJSONValue(fields.ByName(pr.Name(tt.field)), tt.status, tt.oid).PGValue()
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newJSONValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_removeJSONNulls(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		want        string
+		wantIndices []int
+	}{
+		{"no nulls", `[{"id": 1}, {"id": 2}]`, `[{"id": 1}, {"id": 2}]`, nil},
+		{"nulls", `[null, {"id": 1}, null]`, `[{"id":1}]`, []int{0, 2}},
+		{"only null", `[null]`, `[]`, []int{0}},
+		{"null", `null`, `null`, nil},
+		{"object", `{"id": 1}`, `{"id": 1}`, nil},
+		{"invalid", `[`, `[`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, indices, err := removeJSONNulls([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("removeJSONNulls() = %s, want %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(indices, tt.wantIndices) {
+				t.Errorf("removeJSONNulls() indices = %v, want %v", indices, tt.wantIndices)
+			}
+		})
+	}
+}
+
+func Test_jsonValue_aggregate(t *testing.T) {
+	fields := new(support.Parent).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		name    string
+		field   string
+		data    string
+		want    *support.Parent
+		wantErr bool
+	}{
+		{
+			"json_agg",
+			"children",
+			`[{"id":1,"name":"foo","born":"2021-01-02T03:04:05+00:00"}, {"id":2,"name":"bar","born":null}]`,
+			&support.Parent{Children: []*support.Child{
+				{Id: 1, Name: "foo", Born: &timestamppb.Timestamp{Seconds: 1609556645}},
+				{Id: 2, Name: "bar"},
+			}},
+			false,
+		},
+		{
+			"json_agg, null element",
+			"children",
+			`[null]`,
+			&support.Parent{},
+			true,
+		},
+		{
+			"jsonb_build_object",
+			"favorite",
+			`{"id": 3, "name": "baz"}`,
+			&support.Parent{Favorite: &support.Child{Id: 3, Name: "baz"}},
+			false,
+		},
+		{
+			"unknown key",
+			"favorite",
+			`{"id": 3, "foo": "bar"}`,
+			&support.Parent{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := new(Options).New(fields.ByName(pr.Name(tt.field)), pgtype.Undefined, tt.field, pgtype.JSONOID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = v.DecodeText(connInfo, []byte(tt.data)); err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(support.Parent)
+			err = v.SetTo(got.ProtoReflect())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("jsonValue.SetTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("jsonValue.SetTo() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_jsonValue_nullElements(t *testing.T) {
+	fd := new(support.Parent).ProtoReflect().Descriptor().Fields().ByName("children")
+	const data = `[null, {"id": 1}, null]`
+
+	tests := []struct {
+		name    string
+		policy  NullElementPolicy
+		want    *support.Parent
+		wantErr bool
+	}{
+		{"error", NullElementError, &support.Parent{}, true},
+		{"skip", NullElementSkip, &support.Parent{Children: []*support.Child{{Id: 1}}}, false},
+		{"zero", NullElementZero, &support.Parent{Children: []*support.Child{{}, {Id: 1}, {}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := (&Options{NullElements: tt.policy}).New(fd, pgtype.Undefined, "children", pgtype.JSONBOID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = v.DecodeText(connInfo, []byte(data)); err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(support.Parent)
+			err = v.SetTo(got.ProtoReflect())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("jsonValue.SetTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("jsonValue.SetTo() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
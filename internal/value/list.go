@@ -34,9 +34,11 @@ type listValue[T scalar] struct {
 
 func (v *listValue[T]) PGValue() pgtype.Value { return v.ValueTranscoder }
 
-func (v *listValue[T]) SetTo(msg pr.Message) {
+func (v *listValue[T]) SetTo(msg pr.Message) error {
 	var list []T
-	v.AssignTo(&list)
+	if err := v.AssignTo(&list); err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
 
 	pl := msg.NewField(v.fd).List()
 	for _, elem := range list {
@@ -44,35 +46,59 @@ func (v *listValue[T]) SetTo(msg pr.Message) {
 	}
 
 	msg.Set(v.fd, pr.ValueOfList(pl))
+	return nil
+}
+
+func (v *listValue[T]) SetFrom(msg pr.Message) error {
+	return v.Set(listInterface(msg.Get(v.fd).List()))
+}
+
+// listInterface returns the elements of a list as a slice
+// of their Go representation, which can be passed to Set of pgtype array types.
+func listInterface(pl pr.List) []interface{} {
+	list := make([]interface{}, pl.Len())
+	for i := range list {
+		list[i] = pl.Get(i).Interface()
+	}
+
+	return list
 }
-func newlistValue(fd pr.FieldDescriptor, status pgtype.Status) (v Value, err error) {
+
+// newlistValue returns a Value for a repeated scalar field.
+// The oid is the data type of the column, or 0 when unknown.
+func newlistValue(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) (v Value, err error) {
+	vt, err := columnTranscoder(fd, status, oid)
+	if err != nil {
+		return nil, err
+	}
+
 	switch fd.Kind() {
 	case pr.BoolKind:
-		v = &listValue[bool]{fd: fd, ValueTranscoder: &pgtype.BoolArray{Status: status}, valueFunc: pr.ValueOfBool}
+		v = &listValue[bool]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.BoolArray{Status: status}), valueFunc: pr.ValueOfBool}
 
 	case pr.Int32Kind, pr.Sint32Kind, pr.Sfixed32Kind:
-		v = &listValue[int32]{fd: fd, ValueTranscoder: &pgtype.Int4Array{Status: status}, valueFunc: pr.ValueOfInt32}
+		v = &listValue[int32]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Int4Array{Status: status}), valueFunc: pr.ValueOfInt32}
 
 	case pr.Int64Kind, pr.Sint64Kind, pr.Sfixed64Kind:
-		v = &listValue[int64]{fd: fd, ValueTranscoder: &pgtype.Int8Array{Status: status}, valueFunc: pr.ValueOfInt64}
+		v = &listValue[int64]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Int8Array{Status: status}), valueFunc: pr.ValueOfInt64}
 
 	case pr.FloatKind:
-		v = &listValue[float32]{fd: fd, ValueTranscoder: &pgtype.Float4Array{Status: status}, valueFunc: pr.ValueOfFloat32}
+		v = &listValue[float32]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Float4Array{Status: status}), valueFunc: pr.ValueOfFloat32}
 
 	case pr.DoubleKind:
-		v = &listValue[float64]{fd: fd, ValueTranscoder: &pgtype.Float8Array{Status: status}, valueFunc: pr.ValueOfFloat64}
+		v = &listValue[float64]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Float8Array{Status: status}), valueFunc: pr.ValueOfFloat64}
 
 	case pr.StringKind:
-		v = &listValue[string]{fd: fd, ValueTranscoder: &pgtype.TextArray{Status: status}, valueFunc: pr.ValueOfString}
+		v = &listValue[string]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.TextArray{Status: status}), valueFunc: pr.ValueOfString}
 
 	case pr.BytesKind:
-		v = &listValue[[]byte]{fd: fd, ValueTranscoder: &pgtype.ByteaArray{Status: status}, valueFunc: pr.ValueOfBytes}
+		v = &listValue[[]byte]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.ByteaArray{Status: status}), valueFunc: pr.ValueOfBytes}
 
 	case pr.Uint32Kind, pr.Fixed32Kind:
-		v = &listValue[int32]{fd: fd, ValueTranscoder: &pgtype.Int4Array{Status: status}, valueFunc: convertIntValueFunc[int32](pr.ValueOfUint32)}
+		v = &listValue[int32]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Int4Array{Status: status}), valueFunc: convertIntValueFunc[int32](pr.ValueOfUint32)}
 
 	case pr.Uint64Kind, pr.Fixed64Kind:
-		v = &listValue[int64]{fd: fd, ValueTranscoder: &pgtype.Int8Array{Status: status}, valueFunc: convertIntValueFunc[int64](pr.ValueOfUint64)}
+		v = &listValue[int64]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Int8Array{Status: status}), valueFunc: convertIntValueFunc[int64](pr.ValueOfUint64)}
 
 	default:
 		return nil, fmt.Errorf("unsupported type %q", fd.Kind())
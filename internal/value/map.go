@@ -0,0 +1,112 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func isStringMap(fd pr.FieldDescriptor) bool {
+	return fd.IsMap() &&
+		fd.MapKey().Kind() == pr.StringKind &&
+		fd.MapValue().Kind() == pr.StringKind
+}
+
+// hstoreValue scans and writes map<string, string> fields from and to hstore columns.
+type hstoreValue struct {
+	pgtype.Hstore
+	fd pr.FieldDescriptor
+}
+
+func (v *hstoreValue) PGValue() pgtype.Value { return &v.Hstore }
+
+// SetTo sets the map field in msg.
+// NULL values in the hstore are set as empty strings.
+func (v *hstoreValue) SetTo(msg pr.Message) error {
+	if v.Status != pgtype.Present {
+		return nil
+	}
+
+	pm := msg.NewField(v.fd).Map()
+	for key, value := range v.Map {
+		pm.Set(pr.ValueOfString(key).MapKey(), pr.ValueOfString(value.String))
+	}
+
+	msg.Set(v.fd, pr.ValueOfMap(pm))
+	return nil
+}
+
+func (v *hstoreValue) SetFrom(msg pr.Message) error {
+	pm := msg.Get(v.fd).Map()
+	m := make(map[string]string, pm.Len())
+
+	pm.Range(func(key pr.MapKey, value pr.Value) bool {
+		m[key.String()] = value.String()
+		return true
+	})
+
+	return v.Set(m)
+}
+
+// mapEncoding returns the encoding for a map field in a column of type oid.
+// Hstore is used for map<string, string> fields in columns of an unknown or custom type,
+// as the hstore extension does not have a fixed oid.
+// JSON is used in all other cases.
+func mapEncoding(fd pr.FieldDescriptor, oid uint32) Encoding {
+	switch oid {
+	case 0, pgtype.JSONOID, pgtype.JSONBOID, pgtype.TextOID, pgtype.VarcharOID:
+		return JSON
+	}
+
+	if isStringMap(fd) {
+		return Hstore
+	}
+
+	return JSON
+}
+
+func (o *Options) newMapValue(fd pr.FieldDescriptor, status pgtype.Status, enc Encoding, oid uint32) (Value, error) {
+	if enc == Auto {
+		enc = mapEncoding(fd, oid)
+	}
+
+	switch enc {
+	case JSON:
+		return o.newJSONValue(fd, status, oid), nil
+
+	case Hstore:
+		if !isStringMap(fd) {
+			return nil, fmt.Errorf("value: hstore encoding requires a map<string, string> field, not %s", fd.FullName())
+		}
+
+		v := &hstoreValue{pgtype.Hstore{Status: status}, fd}
+		if status == pgtype.Present {
+			v.Map = map[string]pgtype.Text{}
+		}
+
+		return v, nil
+
+	default:
+		return nil, fmt.Errorf("value: encoding %d not supported for map field %s", enc, fd.FullName())
+	}
+}
@@ -0,0 +1,93 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func Test_hstoreValue(t *testing.T) {
+	fd := new(support.Supported).ProtoReflect().Descriptor().Fields().ByName("s_mp")
+	want := &support.Supported{SMp: map[string]string{"foo": "bar", "hello": "world"}}
+
+	v := &hstoreValue{fd: fd}
+	if err := v.SetFrom(want.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	wantHstore := pgtype.Hstore{
+		Map: map[string]pgtype.Text{
+			"foo":   {String: "bar", Status: pgtype.Present},
+			"hello": {String: "world", Status: pgtype.Present},
+		},
+		Status: pgtype.Present,
+	}
+	if !reflect.DeepEqual(v.Hstore, wantHstore) {
+		t.Errorf("hstoreValue.SetFrom =\n%v\nwant\n%v", v.Hstore, wantHstore)
+	}
+
+	got := &support.Supported{}
+	if err := v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(got, want) {
+		t.Errorf("hstoreValue.SetTo =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func Test_newMapValue(t *testing.T) {
+	fields := new(support.Supported).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		name    string
+		field   string
+		enc     Encoding
+		oid     uint32
+		want    Value
+		wantErr bool
+	}{
+		{"auto, unknown oid", "s_mp", Auto, 0, new(Options).newJSONValue(fields.ByName("s_mp"), pgtype.Null, 0), false},
+		{"auto, json", "s_mp", Auto, pgtype.JSONOID, new(Options).newJSONValue(fields.ByName("s_mp"), pgtype.Null, pgtype.JSONOID), false},
+		{"auto, hstore", "s_mp", Auto, 99999, &hstoreValue{pgtype.Hstore{Status: pgtype.Null}, fields.ByName("s_mp")}, false},
+		{"auto, non-string map", "mp", Auto, 99999, new(Options).newJSONValue(fields.ByName("mp"), pgtype.Null, 99999), false},
+		{"hstore", "s_mp", Hstore, 0, &hstoreValue{pgtype.Hstore{Status: pgtype.Null}, fields.ByName("s_mp")}, false},
+		{"hstore error", "mp", Hstore, 0, nil, true},
+		{"unsupported encoding", "mp", EnumName, 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := new(Options).newMapValue(fields.ByName(pr.Name(tt.field)), pgtype.Null, tt.enc, tt.oid)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newMapValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newMapValue() =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
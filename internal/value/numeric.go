@@ -0,0 +1,119 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgtype"
+)
+
+// Limits of the PostgreSQL numeric type.
+const (
+	numericMaxIntegerDigits = 131072
+	numericMaxScale         = 16383
+)
+
+// parseDecimal parses a decimal string, with optional sign, fraction and exponent,
+// such as "-1.5e3", into an unscaled integer and a base 10 exponent.
+// Values beyond the limits of the PostgreSQL numeric type are rejected.
+func parseDecimal(s string) (n *big.Int, exp int32, err error) {
+	var e int64
+
+	mant := s
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mant = s[:i]
+
+		if e, err = strconv.ParseInt(s[i+1:], 10, 64); err != nil {
+			return nil, 0, fmt.Errorf("invalid decimal %q: %w", s, err)
+		}
+	}
+
+	digits := mant
+	if i := strings.IndexByte(mant, '.'); i >= 0 {
+		frac := mant[i+1:]
+		if strings.ContainsAny(frac, "+-") {
+			return nil, 0, fmt.Errorf("invalid decimal %q", s)
+		}
+
+		digits = mant[:i] + frac
+		if e < math.MinInt64+int64(len(frac)) {
+			return nil, 0, fmt.Errorf("decimal %q out of range", s)
+		}
+		e -= int64(len(frac))
+	}
+
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	if n.Sign() == 0 && e > 0 {
+		e = 0
+	}
+	if -e > numericMaxScale || n.Sign() != 0 && int64(len(new(big.Int).Abs(n).String()))+e > numericMaxIntegerDigits {
+		return nil, 0, fmt.Errorf("decimal %q out of range of numeric", s)
+	}
+
+	return n, int32(e), nil
+}
+
+// numericText returns the text format of a present numeric, without exponent,
+// as pgtype.Numeric can not decode its own exponent notation.
+func numericText(n pgtype.Numeric) []byte {
+	if n.NaN {
+		return []byte("NaN")
+	}
+
+	return []byte(formatDecimal(numericInt(n), n.Exp))
+}
+
+// formatDecimal formats n * 10^exp as a decimal string, without exponent.
+func formatDecimal(n *big.Int, exp int32) string {
+	digits := new(big.Int).Abs(n).String()
+
+	var b strings.Builder
+	if n.Sign() < 0 {
+		b.WriteByte('-')
+	}
+
+	if exp >= 0 {
+		b.WriteString(digits)
+		if n.Sign() != 0 {
+			b.WriteString(strings.Repeat("0", int(exp)))
+		}
+		return b.String()
+	}
+
+	scale := int(-exp)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	b.WriteString(digits[:len(digits)-scale])
+	b.WriteByte('.')
+	b.WriteString(digits[len(digits)-scale:])
+
+	return b.String()
+}
@@ -0,0 +1,113 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgtype"
+)
+
+func Test_parseDecimal(t *testing.T) {
+	tests := []struct {
+		s       string
+		wantN   int64
+		wantExp int32
+		wantErr bool
+	}{
+		{"0", 0, 0, false},
+		{"12.34", 1234, -2, false},
+		{"-12.340", -12340, -3, false},
+		{"+.5", 5, -1, false},
+		{"-.5", -5, -1, false},
+		{"1.5e3", 15, 2, false},
+		{"2.5E-3", 25, -4, false},
+		{"100", 100, 0, false},
+		{"", 0, 0, true},
+		{"-", 0, 0, true},
+		{".", 0, 0, true},
+		{"1.-5", 0, 0, true},
+		{"1.5e", 0, 0, true},
+		{"NaN", 0, 0, true},
+		{"0e99999999999", 0, 0, false},
+		{"1e131071", 1, 131071, false},
+		{"1e131072", 0, 0, true},
+		{"1e200000000", 0, 0, true},
+		{"1e-16383", 1, -16383, false},
+		{"0.1e-16383", 0, 0, true},
+		{"1.5e-2147483648", 0, 0, true},
+		{"1.5e-9223372036854775808", 0, 0, true},
+		{"1e99999999999999999999", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			gotN, gotExp, err := parseDecimal(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDecimal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotN.Int64() != tt.wantN || gotExp != tt.wantExp {
+				t.Errorf("parseDecimal() = %v, %v, want %v, %v", gotN, gotExp, tt.wantN, tt.wantExp)
+			}
+		})
+	}
+}
+
+func Test_numericText(t *testing.T) {
+	tests := []struct {
+		n    pgtype.Numeric
+		want string
+	}{
+		{pgtype.Numeric{Int: big.NewInt(15), Exp: 2, Status: pgtype.Present}, "1500"},
+		{pgtype.Numeric{Int: big.NewInt(-15), Exp: -3, Status: pgtype.Present}, "-0.015"},
+		{pgtype.Numeric{Status: pgtype.Present}, "0"},
+		{pgtype.Numeric{NaN: true, Status: pgtype.Present}, "NaN"},
+	}
+	for _, tt := range tests {
+		if got := string(numericText(tt.n)); got != tt.want {
+			t.Errorf("numericText(%v) = %s, want %s", tt.n, got, tt.want)
+		}
+	}
+}
+
+func Test_formatDecimal(t *testing.T) {
+	tests := []struct {
+		n    int64
+		exp  int32
+		want string
+	}{
+		{0, 0, "0"},
+		{0, 2, "0"},
+		{0, -2, "0.00"},
+		{1234, -2, "12.34"},
+		{-1234, -2, "-12.34"},
+		{5, -3, "0.005"},
+		{-5, -1, "-0.5"},
+		{15, 2, "1500"},
+	}
+	for _, tt := range tests {
+		if got := formatDecimal(big.NewInt(tt.n), tt.exp); got != tt.want {
+			t.Errorf("formatDecimal(%d, %d) = %s, want %s", tt.n, tt.exp, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// oneofValue scans and writes the name of the set member of a oneof
+// from and to a text discriminator column.
+// NULL is written when no member is set.
+type oneofValue struct {
+	pgtype.Text
+	od        pr.OneofDescriptor
+	firstWins bool
+}
+
+func (v *oneofValue) PGValue() pgtype.Value { return &v.Text }
+
+// SetTo sets the member named by the scanned value to its zero value,
+// if it is not already set by its own column.
+// An error is returned if another member is already set, unless firstWins is true.
+func (v *oneofValue) SetTo(msg pr.Message) error {
+	if v.Status != pgtype.Present {
+		return nil
+	}
+
+	fd := v.od.Fields().ByName(pr.Name(v.String))
+	if fd == nil {
+		return fmt.Errorf("value: unknown member %q of oneof %s", v.String, v.od.FullName())
+	}
+
+	switch other := msg.WhichOneof(v.od); {
+	case other == nil:
+		msg.Set(fd, msg.NewField(fd))
+	case other.Number() == fd.Number(), v.firstWins:
+	default:
+		return OneofConflict(v.od, other, fd)
+	}
+
+	return nil
+}
+
+func (v *oneofValue) SetFrom(msg pr.Message) error {
+	if fd := msg.WhichOneof(v.od); fd != nil {
+		return v.Set(string(fd.Name()))
+	}
+
+	return v.Set(nil)
+}
+
+// OneofConflict returns the error for a oneof of which the members set and fd are both scanned.
+func OneofConflict(od pr.OneofDescriptor, set, fd pr.FieldDescriptor) error {
+	return fmt.Errorf("value: oneof %s: member %s is set, can't set %s", od.FullName(), set.Name(), fd.Name())
+}
+
+// Oneof returns the oneof from oneofs which maps to the named discriminator column,
+// or nil if there is none. The column name of a oneof is its name.
+// Synthetic oneofs of proto3 optional fields are never returned.
+func (o *Options) Oneof(oneofs pr.OneofDescriptors, column string) pr.OneofDescriptor {
+	foldCase := o != nil && o.FoldCase
+
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+
+		if name := string(od.Name()); name == column || foldCase && strings.EqualFold(name, column) {
+			return od
+		}
+	}
+
+	return nil
+}
+
+// NewOneof returns a Value for the discriminator column of od,
+// which holds the field name of the set member.
+func (o *Options) NewOneof(od pr.OneofDescriptor, status pgtype.Status) Value {
+	return &oneofValue{
+		Text:      pgtype.Text{Status: status},
+		od:        od,
+		firstWins: o != nil && o.OneofFirstWins,
+	}
+}
@@ -0,0 +1,117 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestOptions_Oneof(t *testing.T) {
+	oneofs := (&support.Supported{}).ProtoReflect().Descriptor().Oneofs()
+
+	tests := []struct {
+		name   string
+		opts   *Options
+		column string
+		want   pr.Name
+	}{
+		{"oneof", nil, "o", "o"},
+		{"case", nil, "O", ""},
+		{"fold case", &Options{FoldCase: true}, "O", "o"},
+		{"synthetic", nil, "_o_i32", ""},
+		{"unknown", nil, "foo", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got pr.Name
+			if od := tt.opts.Oneof(oneofs, tt.column); od != nil {
+				got = od.Name()
+			}
+			if got != tt.want {
+				t.Errorf("Options.Oneof() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_oneofValue_SetTo(t *testing.T) {
+	od := (&support.Supported{}).ProtoReflect().Descriptor().Oneofs().ByName("o")
+
+	tests := []struct {
+		name      string
+		msg       *support.Supported
+		src       interface{}
+		firstWins bool
+		want      *support.Supported
+		wantErr   bool
+	}{
+		{"NULL", &support.Supported{}, nil, false, &support.Supported{}, false},
+		{"zero member", &support.Supported{}, "oi", false, &support.Supported{O: &support.Supported_Oi{}}, false},
+		{"same member", &support.Supported{O: &support.Supported_Oi{Oi: 1}}, "oi", false, &support.Supported{O: &support.Supported_Oi{Oi: 1}}, false},
+		{"conflict", &support.Supported{O: &support.Supported_Ob{Ob: true}}, "oi", false, nil, true},
+		{"first wins", &support.Supported{O: &support.Supported_Ob{Ob: true}}, "oi", true, &support.Supported{O: &support.Supported_Ob{Ob: true}}, false},
+		{"unknown member", &support.Supported{}, "foo", false, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := (&Options{OneofFirstWins: tt.firstWins}).NewOneof(od, pgtype.Undefined)
+			if err := v.Set(tt.src); err != nil {
+				t.Fatal(err)
+			}
+
+			err := v.SetTo(tt.msg.ProtoReflect())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("oneofValue.SetTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !proto.Equal(tt.msg, tt.want) {
+				t.Errorf("oneofValue.SetTo() =\n%v\nwant\n%v", tt.msg, tt.want)
+			}
+		})
+	}
+}
+
+func Test_oneofValue_SetFrom(t *testing.T) {
+	od := (&support.Supported{}).ProtoReflect().Descriptor().Oneofs().ByName("o")
+
+	tests := []struct {
+		name string
+		msg  *support.Supported
+		want pgtype.Text
+	}{
+		{"unset", &support.Supported{}, pgtype.Text{Status: pgtype.Null}},
+		{"zero member", &support.Supported{O: &support.Supported_Ob{}}, pgtype.Text{String: "ob", Status: pgtype.Present}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := new(Options).NewOneof(od, pgtype.Present)
+			if err := v.SetFrom(tt.msg.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if got := v.PGValue(); *got.(*pgtype.Text) != tt.want {
+				t.Errorf("oneofValue.SetFrom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/pbpgxpb"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ranges holds the full names of message types registered as range types.
+var ranges = map[pr.FullName]struct{}{}
+
+// RegisterRange registers the message type with the full name as a PostgreSQL range type,
+// for message types without the (pbpgx.range) option.
+func RegisterRange(name pr.FullName) {
+	ranges[name] = struct{}{}
+}
+
+// isRange reports whether messages of md are range types,
+// by the (pbpgx.range) option or registration with RegisterRange.
+func isRange(md pr.MessageDescriptor) bool {
+	if _, ok := ranges[md.FullName()]; ok {
+		return true
+	}
+
+	ok, _ := proto.GetExtension(md.Options(), pbpgxpb.E_Range).(bool)
+	return ok
+}
+
+// rangeFields are the fields of a range message.
+// Lower and upper are required, the others are nil when absent.
+type rangeFields struct {
+	lower, upper                   pr.FieldDescriptor
+	lowerInclusive, upperInclusive pr.FieldDescriptor
+	empty                          pr.FieldDescriptor
+}
+
+func newRangeFields(md pr.MessageDescriptor) (rf rangeFields, err error) {
+	fields := md.Fields()
+
+	rf.lower, rf.upper = fields.ByName("lower"), fields.ByName("upper")
+	for _, fd := range []pr.FieldDescriptor{rf.lower, rf.upper} {
+		if fd == nil {
+			return rf, fmt.Errorf("value: range type %s needs lower and upper fields", md.FullName())
+		}
+		if fd.IsList() || fd.IsMap() || !fd.HasPresence() {
+			return rf, fmt.Errorf("value: range bound %s must be a message or optional field", fd.FullName())
+		}
+	}
+
+	rf.lowerInclusive, rf.upperInclusive = fields.ByName("lower_inclusive"), fields.ByName("upper_inclusive")
+	rf.empty = fields.ByName("empty")
+
+	for _, fd := range []pr.FieldDescriptor{rf.lowerInclusive, rf.upperInclusive, rf.empty} {
+		if fd != nil && (fd.Kind() != pr.BoolKind || fd.IsList()) {
+			return rf, fmt.Errorf("value: range field %s must be of type bool", fd.FullName())
+		}
+	}
+
+	return rf, nil
+}
+
+// newRange returns the pgtype range type for a column of type oid.
+// For other columns the range type is derived from the type of the lower bound:
+// google.protobuf.Timestamp for tstzrange, google.type.Date for daterange,
+// 32 bit integers for int4range, 64 bit integers for int8range
+// and google.type.Decimal, string or floating point fields for numrange.
+func newRange(bound pr.FieldDescriptor, status pgtype.Status, oid uint32) (pgtype.ValueTranscoder, error) {
+	switch oid {
+	case pgtype.TstzrangeOID:
+		return &pgtype.Tstzrange{Status: status}, nil
+	case pgtype.DaterangeOID:
+		return &pgtype.Daterange{Status: status}, nil
+	case pgtype.Int4rangeOID:
+		return &pgtype.Int4range{Status: status}, nil
+	case pgtype.Int8rangeOID:
+		return &pgtype.Int8range{Status: status}, nil
+	case pgtype.NumrangeOID:
+		return &pgtype.Numrange{Status: status}, nil
+	}
+
+	if md := bound.Message(); md != nil {
+		switch md.FullName() {
+		case SupportedTimestamp:
+			return &pgtype.Tstzrange{Status: status}, nil
+		case SupportedDate:
+			return &pgtype.Daterange{Status: status}, nil
+		case SupportedDecimal:
+			return &pgtype.Numrange{Status: status}, nil
+		}
+	} else {
+		switch bound.Kind() {
+		case pr.Int32Kind, pr.Sint32Kind, pr.Sfixed32Kind:
+			return &pgtype.Int4range{Status: status}, nil
+		case pr.Int64Kind, pr.Sint64Kind, pr.Sfixed64Kind, pr.Uint32Kind, pr.Fixed32Kind:
+			return &pgtype.Int8range{Status: status}, nil
+		case pr.Uint64Kind, pr.Fixed64Kind, pr.FloatKind, pr.DoubleKind, pr.StringKind:
+			return &pgtype.Numrange{Status: status}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("value: no range type for bound %s", bound.FullName())
+}
+
+// rangeBounds returns the bounds and bound types of a pgtype range type.
+// Range types have no common interface, but share the same fields.
+func rangeBounds(r pgtype.Value) (lower, upper pgtype.ValueTranscoder, lowerType, upperType *pgtype.BoundType) {
+	rv := reflect.ValueOf(r).Elem()
+
+	return rv.FieldByName("Lower").Addr().Interface().(pgtype.ValueTranscoder),
+		rv.FieldByName("Upper").Addr().Interface().(pgtype.ValueTranscoder),
+		rv.FieldByName("LowerType").Addr().Interface().(*pgtype.BoundType),
+		rv.FieldByName("UpperType").Addr().Interface().(*pgtype.BoundType)
+}
+
+// boundText returns the text format of a range bound, or of the Value of a bound field.
+// Numeric bounds are encoded without exponent, see numericText.
+func boundText(bound pgtype.ValueTranscoder) ([]byte, error) {
+	var pv pgtype.Value = bound
+	if v, ok := bound.(Value); ok {
+		pv = v.PGValue()
+	}
+	if n, ok := pv.(*pgtype.Numeric); ok && n.Status == pgtype.Present {
+		return numericText(*n), nil
+	}
+
+	return bound.EncodeText(connInfo, nil)
+}
+
+// rangeValue scans and writes message fields of range types from and to range columns.
+// Bounds are converted by the Values of the lower and upper fields, in text format.
+type rangeValue struct {
+	pgtype.ValueTranscoder
+	o  *Options
+	fd pr.FieldDescriptor
+	rf rangeFields
+}
+
+func (o *Options) newRangeValue(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) (Value, error) {
+	if fd.IsList() {
+		return nil, fmt.Errorf("value: repeated range field %s not supported", fd.FullName())
+	}
+
+	rf, err := newRangeFields(fd.Message())
+	if err != nil {
+		return nil, err
+	}
+
+	vt, err := newRange(rf.lower, status, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	// The zero value of a range message is unbounded.
+	if status == pgtype.Present {
+		_, _, lowerType, upperType := rangeBounds(vt)
+		*lowerType, *upperType = pgtype.Unbounded, pgtype.Unbounded
+	}
+
+	return &rangeValue{vt, o, fd, rf}, nil
+}
+
+func (v *rangeValue) PGValue() pgtype.Value { return v.ValueTranscoder }
+
+// boundValue returns a Value for a bound field of the range.
+// Unsigned bounds use UnsignedWide when the element type of the range
+// is wider than the signed integer type of the same size,
+// such as int8range for uint32 and numrange for uint64.
+func (v *rangeValue) boundValue(fd pr.FieldDescriptor, status pgtype.Status) (Value, error) {
+	if !isUnsignedKind(fd.Kind()) {
+		return v.o.New(fd, status, "", 0)
+	}
+
+	var oid uint32
+	switch v.ValueTranscoder.(type) {
+	case *pgtype.Int4range:
+		oid = pgtype.Int4OID
+	case *pgtype.Int8range:
+		oid = pgtype.Int8OID
+	case *pgtype.Numrange:
+		oid = pgtype.NumericOID
+	}
+
+	return newUnsignedValue(fd, status, unsignedEncoding(fd, oid), 0)
+}
+
+func (v *rangeValue) SetTo(msg pr.Message) error {
+	if v.Get() == nil {
+		return nil
+	}
+
+	lower, upper, lowerType, upperType := rangeBounds(v.ValueTranscoder)
+	m := msg.NewField(v.fd).Message()
+
+	if *lowerType == pgtype.Empty {
+		if v.rf.empty == nil {
+			return fmt.Errorf("value: empty range for field %s, without empty field", v.fd.FullName())
+		}
+
+		m.Set(v.rf.empty, pr.ValueOfBool(true))
+		msg.Set(v.fd, pr.ValueOfMessage(m))
+		return nil
+	}
+
+	if err := v.setBound(m, v.rf.lower, v.rf.lowerInclusive, lower, *lowerType, pgtype.Inclusive); err != nil {
+		return err
+	}
+	if err := v.setBound(m, v.rf.upper, v.rf.upperInclusive, upper, *upperType, pgtype.Exclusive); err != nil {
+		return err
+	}
+
+	msg.Set(v.fd, pr.ValueOfMessage(m))
+	return nil
+}
+
+// setBound sets the bound field and its inclusivity field in m, from a bound of the range.
+// Bounds which differ from the default bound type can only be set when the inclusivity field exists.
+func (v *rangeValue) setBound(m pr.Message, fd, inclusive pr.FieldDescriptor, bound pgtype.ValueTranscoder, bt, def pgtype.BoundType) error {
+	if bt == pgtype.Unbounded {
+		return nil
+	}
+
+	if inclusive != nil {
+		m.Set(inclusive, pr.ValueOfBool(bt == pgtype.Inclusive))
+	} else if bt != def {
+		return fmt.Errorf("value: range bound %s is not %s, without %s field", fd.FullName(), boundTypeName(def), fd.Name()+"_inclusive")
+	}
+
+	text, err := boundText(bound)
+	if err != nil {
+		return fmt.Errorf("value: range bound %s: %w", fd.FullName(), err)
+	}
+
+	bv, err := v.boundValue(fd, pgtype.Undefined)
+	if err != nil {
+		return err
+	}
+	if err = bv.DecodeText(connInfo, text); err != nil {
+		return fmt.Errorf("value: range bound %s: %w", fd.FullName(), err)
+	}
+
+	return bv.SetTo(m)
+}
+
+func (v *rangeValue) SetFrom(msg pr.Message) error {
+	lower, upper, lowerType, upperType := rangeBounds(v.ValueTranscoder)
+	m := msg.Get(v.fd).Message()
+
+	if v.rf.empty != nil && m.Get(v.rf.empty).Bool() {
+		*lowerType, *upperType = pgtype.Empty, pgtype.Empty
+		setRangeStatus(v.ValueTranscoder, pgtype.Present)
+		return nil
+	}
+
+	var err error
+	if *lowerType, err = v.bound(m, v.rf.lower, v.rf.lowerInclusive, lower, pgtype.Inclusive); err != nil {
+		return err
+	}
+	if *upperType, err = v.bound(m, v.rf.upper, v.rf.upperInclusive, upper, pgtype.Exclusive); err != nil {
+		return err
+	}
+
+	setRangeStatus(v.ValueTranscoder, pgtype.Present)
+	return nil
+}
+
+// bound sets a bound of the range from the bound field in m,
+// and returns its bound type.
+// Unset inclusivity fields with presence result in the default bound type.
+func (v *rangeValue) bound(m pr.Message, fd, inclusive pr.FieldDescriptor, bound pgtype.ValueTranscoder, def pgtype.BoundType) (pgtype.BoundType, error) {
+	if !m.Has(fd) {
+		return pgtype.Unbounded, nil
+	}
+
+	bv, err := v.boundValue(fd, pgtype.Present)
+	if err != nil {
+		return 0, err
+	}
+	if err = bv.SetFrom(m); err != nil {
+		return 0, err
+	}
+
+	text, err := boundText(bv)
+	if err != nil {
+		return 0, fmt.Errorf("value: range bound %s: %w", fd.FullName(), err)
+	}
+	if err = bound.DecodeText(connInfo, text); err != nil {
+		return 0, fmt.Errorf("value: range bound %s: %w", fd.FullName(), err)
+	}
+
+	if inclusive == nil || inclusive.HasPresence() && !m.Has(inclusive) {
+		return def, nil
+	}
+	if m.Get(inclusive).Bool() {
+		return pgtype.Inclusive, nil
+	}
+
+	return pgtype.Exclusive, nil
+}
+
+func setRangeStatus(r pgtype.Value, status pgtype.Status) {
+	reflect.ValueOf(r).Elem().FieldByName("Status").Set(reflect.ValueOf(status))
+}
+
+func boundTypeName(bt pgtype.BoundType) string {
+	if bt == pgtype.Inclusive {
+		return "inclusive"
+	}
+
+	return "exclusive"
+}
@@ -0,0 +1,208 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_isRange(t *testing.T) {
+	RegisterRange("support.Span")
+
+	tests := []struct {
+		name string
+		md   pr.MessageDescriptor
+		want bool
+	}{
+		{"option", (&support.TimeRange{}).ProtoReflect().Descriptor(), true},
+		{"registered", (&support.Span{}).ProtoReflect().Descriptor(), true},
+		{"other", (&support.Child{}).ProtoReflect().Descriptor(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRange(tt.md); got != tt.want {
+				t.Errorf("isRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newRangeFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		md      pr.MessageDescriptor
+		wantErr bool
+	}{
+		{"messages", (&support.TimeRange{}).ProtoReflect().Descriptor(), false},
+		{"optional", (&support.IntRange{}).ProtoReflect().Descriptor(), false},
+		{"no bounds", (&support.Child{}).ProtoReflect().Descriptor(), true},
+		{"no presence", (&support.Row{}).ProtoReflect().Descriptor(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newRangeFields(tt.md); (err != nil) != tt.wantErr {
+				t.Errorf("newRangeFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_rangeValue_SetTo(t *testing.T) {
+	ts := func(sec int64) *timestamppb.Timestamp { return timestamppb.New(time.Unix(sec, 0)) }
+
+	tests := []struct {
+		name    string
+		field   pr.Name
+		oid     uint32
+		src     string
+		want    *support.Booking
+		wantErr bool
+	}{
+		{"NULL", "window", pgtype.TstzrangeOID, "", &support.Booking{}, false},
+		{
+			"tstzrange", "window", pgtype.TstzrangeOID, `["1970-01-01 00:00:01Z","1970-01-01 00:00:02Z")`,
+			&support.Booking{Window: &support.TimeRange{Lower: ts(1), Upper: ts(2), LowerInclusive: proto.Bool(true), UpperInclusive: proto.Bool(false)}}, false,
+		},
+		{
+			"tstzrange unbounded", "window", pgtype.TstzrangeOID, `(,"1970-01-01 00:00:02Z"]`,
+			&support.Booking{Window: &support.TimeRange{Upper: ts(2), UpperInclusive: proto.Bool(true)}}, false,
+		},
+		{"tstzrange empty", "window", pgtype.TstzrangeOID, "empty", &support.Booking{Window: &support.TimeRange{Empty: true}}, false},
+		{
+			"daterange", "days", pgtype.DaterangeOID, "[2022-02-01,2022-03-01)",
+			&support.Booking{Days: &support.DateRange{Lower: &date.Date{Year: 2022, Month: 2, Day: 1}, Upper: &date.Date{Year: 2022, Month: 3, Day: 1}}}, false,
+		},
+		{"daterange exclusive lower", "days", pgtype.DaterangeOID, "(2022-02-01,2022-03-01)", nil, true},
+		{"daterange empty", "days", pgtype.DaterangeOID, "empty", nil, true},
+		{"int8range", "seats", pgtype.Int8rangeOID, "[1,10)", &support.Booking{Seats: &support.IntRange{Lower: proto.Int64(1), Upper: proto.Int64(10)}}, false},
+		{"int8range zero", "seats", pgtype.Int8rangeOID, "[0,)", &support.Booking{Seats: &support.IntRange{Lower: proto.Int64(0)}}, false},
+		{"int4range", "seats", pgtype.Int4rangeOID, "[-1,1)", &support.Booking{Seats: &support.IntRange{Lower: proto.Int64(-1), Upper: proto.Int64(1)}}, false},
+		{"int8range empty", "seats", pgtype.Int8rangeOID, "empty", &support.Booking{Seats: &support.IntRange{Empty: true}}, false},
+		{
+			"numrange", "price", pgtype.NumrangeOID, "(1.5,99.95]",
+			&support.Booking{Price: &support.NumRange{Lower: proto.String("1.5"), Upper: proto.String("99.95"), UpperInclusive: true}}, false,
+		},
+		{
+			"numrange uint64", "serials", pgtype.NumrangeOID, "[1,18446744073709551615)",
+			&support.Booking{Serials: &support.UintRange{Lower: proto.Uint64(1), Upper: proto.Uint64(math.MaxUint64)}}, false,
+		},
+		{"numrange uint64 overflow", "serials", pgtype.NumrangeOID, "[1,18446744073709551616)", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := (&support.Booking{}).ProtoReflect().Descriptor().Fields().ByName(tt.field)
+
+			v, err := new(Options).New(fd, pgtype.Undefined, string(tt.field), tt.oid)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var src []byte
+			if tt.src != "" {
+				src = []byte(tt.src)
+			}
+			if err = v.DecodeText(connInfo, src); err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(support.Booking)
+			err = v.SetTo(got.ProtoReflect())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rangeValue.SetTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !proto.Equal(got, tt.want) {
+				t.Errorf("rangeValue.SetTo() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rangeValue_SetFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   pr.Name
+		msg     *support.Booking
+		want    string
+		wantErr bool
+	}{
+		{
+			"tstzrange default bounds", "window",
+			&support.Booking{Window: &support.TimeRange{Lower: &timestamppb.Timestamp{Seconds: 1}, Upper: &timestamppb.Timestamp{Seconds: 2}}},
+			"[1970-01-01 00:00:01Z,1970-01-01 00:00:02Z)", false,
+		},
+		{
+			"tstzrange inclusive", "window",
+			&support.Booking{Window: &support.TimeRange{Lower: &timestamppb.Timestamp{Seconds: 1}, LowerInclusive: proto.Bool(false), UpperInclusive: proto.Bool(true)}},
+			"(1970-01-01 00:00:01Z,)", false,
+		},
+		{"tstzrange empty", "window", &support.Booking{Window: &support.TimeRange{Empty: true}}, "empty", false},
+		{"unbounded", "window", &support.Booking{Window: &support.TimeRange{}}, "(,)", false},
+		{"unset", "window", &support.Booking{}, "(,)", false},
+		{
+			"daterange", "days",
+			&support.Booking{Days: &support.DateRange{Lower: &date.Date{Year: 2022, Month: 2, Day: 1}, Upper: &date.Date{Year: 2022, Month: 3, Day: 1}}},
+			"[2022-02-01,2022-03-01)", false,
+		},
+		{"int8range", "seats", &support.Booking{Seats: &support.IntRange{Lower: proto.Int64(0), Upper: proto.Int64(10)}}, "[0,10)", false},
+		{"int8range empty", "seats", &support.Booking{Seats: &support.IntRange{Empty: true}}, "empty", false},
+		{"numrange", "price", &support.Booking{Price: &support.NumRange{Lower: proto.String("1.5"), UpperInclusive: true}}, "(15e-1,)", false},
+		{"numrange invalid", "price", &support.Booking{Price: &support.NumRange{Lower: proto.String("foo")}}, "", true},
+		{
+			"numrange uint64", "serials",
+			&support.Booking{Serials: &support.UintRange{Lower: proto.Uint64(math.MaxInt64 + 1), Upper: proto.Uint64(math.MaxUint64)}},
+			"[9223372036854775808e0,18446744073709551615e0)", false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := (&support.Booking{}).ProtoReflect().Descriptor().Fields().ByName(tt.field)
+
+			v, err := new(Options).New(fd, pgtype.Present, string(tt.field), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = v.SetFrom(tt.msg.ProtoReflect())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rangeValue.SetFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			buf, err := v.EncodeText(connInfo, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(buf); got != tt.want {
+				t.Errorf("rangeValue.SetFrom() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
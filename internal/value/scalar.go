@@ -47,10 +47,26 @@ type scalarValue[T scalar] struct {
 
 func (v *scalarValue[T]) PGValue() pgtype.Value { return v.ValueTranscoder }
 
-func (v *scalarValue[T]) SetTo(msg pr.Message) {
-	if pgv, ok := v.Get().(T); ok {
-		msg.Set(v.fd, v.valueFunc(pgv))
+// SetTo sets the field, when the value is present.
+// NULL values leave the field unset, so that optional fields are not populated.
+// The value is converted to T with range checks, as the column type may differ from the field type.
+func (v *scalarValue[T]) SetTo(msg pr.Message) error {
+	switch v.Get().(type) {
+	case nil, pgtype.Status:
+		return nil
 	}
+
+	var x T
+	if err := v.AssignTo(&x); err != nil {
+		return fmt.Errorf("value: field %s: %w", v.fd.FullName(), err)
+	}
+
+	msg.Set(v.fd, v.valueFunc(x))
+	return nil
+}
+
+func (v *scalarValue[T]) SetFrom(msg pr.Message) error {
+	return v.Set(msg.Get(v.fd).Interface())
 }
 
 // convertIntValueFunc returns a function which calls passed Value function
@@ -61,34 +77,50 @@ func convertIntValueFunc[A, B constraints.Integer](f func(B) pr.Value) func(a A)
 	}
 }
 
-func newScalarValue(fd pr.FieldDescriptor, status pgtype.Status) (v Value, err error) {
+// orTranscoder returns vt, or def if vt is nil.
+func orTranscoder(vt, def pgtype.ValueTranscoder) pgtype.ValueTranscoder {
+	if vt != nil {
+		return vt
+	}
+
+	return def
+}
+
+// newScalarValue returns a Value for a scalar field.
+// The oid is the data type of the column, or 0 when unknown.
+func newScalarValue(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) (v Value, err error) {
+	vt, err := columnTranscoder(fd, status, oid)
+	if err != nil {
+		return nil, err
+	}
+
 	switch fd.Kind() {
 	case pr.BoolKind:
-		v = &scalarValue[bool]{fd: fd, ValueTranscoder: &pgtype.Bool{Status: status}, valueFunc: pr.ValueOfBool}
+		v = &scalarValue[bool]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Bool{Status: status}), valueFunc: pr.ValueOfBool}
 
 	case pr.Int32Kind, pr.Sint32Kind, pr.Sfixed32Kind:
-		v = &scalarValue[int32]{fd: fd, ValueTranscoder: &pgtype.Int4{Status: status}, valueFunc: pr.ValueOfInt32}
+		v = &scalarValue[int32]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Int4{Status: status}), valueFunc: pr.ValueOfInt32}
 
 	case pr.Int64Kind, pr.Sint64Kind, pr.Sfixed64Kind:
-		v = &scalarValue[int64]{fd: fd, ValueTranscoder: &pgtype.Int8{Status: status}, valueFunc: pr.ValueOfInt64}
+		v = &scalarValue[int64]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Int8{Status: status}), valueFunc: pr.ValueOfInt64}
 
 	case pr.FloatKind:
-		v = &scalarValue[float32]{fd: fd, ValueTranscoder: &pgtype.Float4{Status: status}, valueFunc: pr.ValueOfFloat32}
+		v = &scalarValue[float32]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Float4{Status: status}), valueFunc: pr.ValueOfFloat32}
 
 	case pr.DoubleKind:
-		v = &scalarValue[float64]{fd: fd, ValueTranscoder: &pgtype.Float8{Status: status}, valueFunc: pr.ValueOfFloat64}
+		v = &scalarValue[float64]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Float8{Status: status}), valueFunc: pr.ValueOfFloat64}
 
 	case pr.StringKind:
-		v = &scalarValue[string]{fd: fd, ValueTranscoder: &pgtype.Text{Status: status}, valueFunc: pr.ValueOfString}
+		v = &scalarValue[string]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Text{Status: status}), valueFunc: pr.ValueOfString}
 
 	case pr.BytesKind:
-		v = &scalarValue[[]byte]{fd: fd, ValueTranscoder: &pgtype.Bytea{Status: status}, valueFunc: pr.ValueOfBytes}
+		v = &scalarValue[[]byte]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Bytea{Status: status}), valueFunc: pr.ValueOfBytes}
 
 	case pr.Uint32Kind, pr.Fixed32Kind:
-		v = &scalarValue[int32]{fd: fd, ValueTranscoder: &pgtype.Int4{Status: status}, valueFunc: convertIntValueFunc[int32](pr.ValueOfUint32)}
+		v = &scalarValue[int32]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Int4{Status: status}), valueFunc: convertIntValueFunc[int32](pr.ValueOfUint32)}
 
 	case pr.Uint64Kind, pr.Fixed64Kind:
-		v = &scalarValue[int64]{fd: fd, ValueTranscoder: &pgtype.Int8{Status: status}, valueFunc: convertIntValueFunc[int64](pr.ValueOfUint64)}
+		v = &scalarValue[int64]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Int8{Status: status}), valueFunc: convertIntValueFunc[int64](pr.ValueOfUint64)}
 
 	default:
 		return nil, fmt.Errorf("unsupported type %q", fd.Kind())
@@ -0,0 +1,233 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const macaddrArrayOID = 1040
+
+// stringEncoding returns the encoding of string and bytes fields, for columns of type oid.
+// An encoding for uuid, network address or numeric columns set for the column takes precedence.
+// Auto is returned for other columns, which use the default transcoder of the field kind.
+func stringEncoding(enc Encoding, oid uint32) Encoding {
+	switch enc {
+	case UUID, Inet, Macaddr, Numeric:
+		return enc
+	}
+
+	switch oid {
+	case pgtype.UUIDOID, pgtype.UUIDArrayOID:
+		return UUID
+	case pgtype.InetOID, pgtype.InetArrayOID, pgtype.CIDROID, pgtype.CIDRArrayOID:
+		return Inet
+	case pgtype.MacaddrOID, macaddrArrayOID:
+		return Macaddr
+	case pgtype.NumericOID, pgtype.NumericArrayOID:
+		return Numeric
+	default:
+		return Auto
+	}
+}
+
+// inetString converts strings from and to inet and cidr values.
+// Strings are in the text format of PostgreSQL,
+// which omits the netmask of inet host addresses.
+type inetString struct {
+	pgtype.Inet
+	cidr bool
+}
+
+// Set accepts host addresses without netmask, as well as addresses in CIDR notation.
+func (e *inetString) Set(src interface{}) error {
+	if s, ok := src.(string); ok {
+		return e.Inet.DecodeText(nil, []byte(s))
+	}
+
+	return e.Inet.Set(src)
+}
+
+func (e *inetString) AssignTo(dst interface{}) error {
+	s, ok := dst.(*string)
+	if !ok || e.Status != pgtype.Present {
+		return e.Inet.AssignTo(dst)
+	}
+
+	if ones, bits := e.IPNet.Mask.Size(); ones == bits && !e.cidr {
+		*s = e.IPNet.IP.String()
+	} else {
+		*s = e.IPNet.String()
+	}
+
+	return nil
+}
+
+// numericString converts decimal strings from and to numeric values, without loss of precision.
+type numericString struct {
+	pgtype.Numeric
+}
+
+// Set accepts decimal strings within the limits of the numeric type, and NaN.
+func (e *numericString) Set(src interface{}) error {
+	switch s := src.(type) {
+	case string:
+		if s == "NaN" {
+			e.Numeric = pgtype.Numeric{NaN: true, Status: pgtype.Present}
+			return nil
+		}
+
+		n, exp, err := parseDecimal(s)
+		if err != nil {
+			return err
+		}
+
+		e.Numeric = pgtype.Numeric{Int: n, Exp: exp, Status: pgtype.Present}
+		return nil
+
+	case pgtype.Numeric:
+		e.Numeric = s
+		return nil
+
+	default:
+		return e.Numeric.Set(src)
+	}
+}
+
+func (e *numericString) AssignTo(dst interface{}) error {
+	s, ok := dst.(*string)
+	if !ok || e.Status != pgtype.Present {
+		return e.Numeric.AssignTo(dst)
+	}
+
+	*s = string(numericText(e.Numeric))
+	return nil
+}
+
+// EncodeText encodes the numeric without exponent, see numericText.
+func (e numericString) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	if e.Status != pgtype.Present {
+		return e.Numeric.EncodeText(ci, buf)
+	}
+
+	return append(buf, numericText(e.Numeric)...), nil
+}
+
+// stringType describes the PostgreSQL type of string and bytes fields with an Encoding
+// for uuid, network address or numeric columns.
+type stringType struct {
+	name string // Type name, used for the array type name.
+	oid  uint32
+
+	// new returns a transcoder with status, which converts from and to strings.
+	// UUIDs are converted from and to 16 byte slices as well.
+	new func(status pgtype.Status) pgtype.ValueTranscoder
+}
+
+func newStringType(enc Encoding, oid uint32) (t stringType, ok bool) {
+	switch enc {
+	case UUID:
+		return stringType{"uuid", pgtype.UUIDOID, func(status pgtype.Status) pgtype.ValueTranscoder {
+			return &pgtype.UUID{Status: status}
+		}}, true
+
+	case Inet:
+		t := stringType{"inet", pgtype.InetOID, func(status pgtype.Status) pgtype.ValueTranscoder {
+			return &inetString{Inet: pgtype.Inet{Status: status}}
+		}}
+		if oid == pgtype.CIDROID || oid == pgtype.CIDRArrayOID {
+			t = stringType{"cidr", pgtype.CIDROID, func(status pgtype.Status) pgtype.ValueTranscoder {
+				return &inetString{Inet: pgtype.Inet{Status: status}, cidr: true}
+			}}
+		}
+		return t, true
+
+	case Macaddr:
+		return stringType{"macaddr", pgtype.MacaddrOID, func(status pgtype.Status) pgtype.ValueTranscoder {
+			return &pgtype.Macaddr{Status: status}
+		}}, true
+
+	case Numeric:
+		return stringType{"numeric", pgtype.NumericOID, func(status pgtype.Status) pgtype.ValueTranscoder {
+			return &numericString{pgtype.Numeric{Int: new(big.Int), Status: status}}
+		}}, true
+
+	default:
+		return t, false
+	}
+}
+
+// inetListValue scans and writes repeated string fields from and to inet or cidr array columns.
+type inetListValue struct {
+	*listValue[string]
+}
+
+// PreferredParamFormat returns the text format code.
+// The binary array format contains the element type,
+// which will not match with the type of a cidr array column.
+func (v inetListValue) PreferredParamFormat() int16 { return pgtype.TextFormatCode }
+
+// newStringValue returns a Value for a string or bytes field,
+// in a uuid, network address or numeric column.
+// Bytes fields are only supported with the UUID encoding, as 16 byte UUIDs.
+// Empty singular fields are written as NULL, except for numeric columns,
+// as the empty string is not valid for the other types.
+func newStringValue(fd pr.FieldDescriptor, status pgtype.Status, enc Encoding, oid uint32) (Value, error) {
+	t, ok := newStringType(enc, oid)
+	if !ok || fd.Kind() != pr.StringKind && (fd.Kind() != pr.BytesKind || enc != UUID) {
+		return nil, fmt.Errorf("value: encoding %d not supported for field %s of kind %s", enc, fd.FullName(), fd.Kind())
+	}
+
+	if !fd.IsList() {
+		if enc != Numeric && status == pgtype.Present {
+			status = pgtype.Null
+		}
+
+		if fd.Kind() == pr.BytesKind {
+			return &scalarValue[[]byte]{fd: fd, ValueTranscoder: t.new(status), valueFunc: pr.ValueOfBytes}, nil
+		}
+		return &scalarValue[string]{fd: fd, ValueTranscoder: t.new(status), valueFunc: pr.ValueOfString}, nil
+	}
+
+	at := pgtype.NewArrayType("_"+t.name, t.oid, func() pgtype.ValueTranscoder {
+		return t.new(pgtype.Undefined)
+	})
+
+	switch status {
+	case pgtype.Null:
+		at.Set(nil)
+	case pgtype.Present:
+		at.Set([]string{})
+	}
+
+	if fd.Kind() == pr.BytesKind {
+		return &listValue[[]byte]{fd: fd, ValueTranscoder: at, valueFunc: pr.ValueOfBytes}, nil
+	}
+
+	v := &listValue[string]{fd: fd, ValueTranscoder: at, valueFunc: pr.ValueOfString}
+	if enc == Inet {
+		return inetListValue{v}, nil
+	}
+	return v, nil
+}
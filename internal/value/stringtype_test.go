@@ -0,0 +1,220 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func Test_stringEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		enc  Encoding
+		oid  uint32
+		want Encoding
+	}{
+		{"unknown", Auto, 0, Auto},
+		{"text", Auto, pgtype.TextOID, Auto},
+		{"uuid", Auto, pgtype.UUIDOID, UUID},
+		{"uuid array", Auto, pgtype.UUIDArrayOID, UUID},
+		{"inet", Auto, pgtype.InetOID, Inet},
+		{"cidr", Auto, pgtype.CIDROID, Inet},
+		{"macaddr", Auto, pgtype.MacaddrOID, Macaddr},
+		{"numeric", Auto, pgtype.NumericOID, Numeric},
+		{"encoding", UUID, 0, UUID},
+		{"other encoding", JSON, pgtype.TextOID, Auto},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stringEncoding(tt.enc, tt.oid); got != tt.want {
+				t.Errorf("stringEncoding() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newStringValue_SetTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   pr.Name
+		oid     uint32
+		src     string
+		want    *support.Supported
+		wantErr bool
+	}{
+		{"NULL", "s", pgtype.UUIDOID, "", &support.Supported{}, false},
+		{"uuid", "s", pgtype.UUIDOID, "A0EEBC99-9C0B-4EF8-BB6D-6BB9BD380A11", &support.Supported{S: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"}, false},
+		{
+			"uuid bytes", "bt", pgtype.UUIDOID, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+			&support.Supported{Bt: []byte{0xa0, 0xee, 0xbc, 0x99, 0x9c, 0x0b, 0x4e, 0xf8, 0xbb, 0x6d, 0x6b, 0xb9, 0xbd, 0x38, 0x0a, 0x11}}, false,
+		},
+		{"inet host", "s", pgtype.InetOID, "192.168.0.1", &support.Supported{S: "192.168.0.1"}, false},
+		{"inet network", "s", pgtype.InetOID, "192.168.0.1/24", &support.Supported{S: "192.168.0.1/24"}, false},
+		{"inet IPv6", "s", pgtype.InetOID, "::1", &support.Supported{S: "::1"}, false},
+		{"cidr", "s", pgtype.CIDROID, "10.0.0.0/8", &support.Supported{S: "10.0.0.0/8"}, false},
+		{"cidr host", "s", pgtype.CIDROID, "10.0.0.1/32", &support.Supported{S: "10.0.0.1/32"}, false},
+		{"macaddr", "s", pgtype.MacaddrOID, "08:00:2B:01:02:03", &support.Supported{S: "08:00:2b:01:02:03"}, false},
+		{"numeric", "s", pgtype.NumericOID, "123456789012345678901234567890.000000001", &support.Supported{S: "123456789012345678901234567890.000000001"}, false},
+		{"numeric scale", "s", pgtype.NumericOID, "1.50", &support.Supported{S: "1.50"}, false},
+		{"numeric NaN", "s", pgtype.NumericOID, "NaN", &support.Supported{S: "NaN"}, false},
+		{"uuid array", "r_s", pgtype.UUIDArrayOID, "{a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11}", &support.Supported{RS: []string{"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"}}, false},
+		{"inet array", "r_s", pgtype.InetArrayOID, "{10.0.0.1,10.0.0.0/8}", &support.Supported{RS: []string{"10.0.0.1", "10.0.0.0/8"}}, false},
+		{"numeric array", "r_s", pgtype.NumericArrayOID, "{1.5,-0.001}", &support.Supported{RS: []string{"1.5", "-0.001"}}, false},
+		{"numeric array NULL", "r_s", pgtype.NumericArrayOID, "{1.5,NULL}", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := (&support.Supported{}).ProtoReflect().Descriptor().Fields().ByName(tt.field)
+
+			v, err := new(Options).New(fd, pgtype.Undefined, string(tt.field), tt.oid)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var src []byte
+			if tt.src != "" {
+				src = []byte(tt.src)
+			}
+			if err = v.DecodeText(connInfo, src); err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(support.Supported)
+			err = v.SetTo(got.ProtoReflect())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Value.SetTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !proto.Equal(got, tt.want) {
+				t.Errorf("Value.SetTo() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newStringValue_SetFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   pr.Name
+		enc     Encoding
+		oid     uint32 // Column type for scanning the written value.
+		msg     *support.Supported
+		wantErr bool
+	}{
+		{"uuid", "s", UUID, pgtype.UUIDOID, &support.Supported{S: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"}, false},
+		{"uuid bytes", "bt", UUID, pgtype.UUIDOID, &support.Supported{Bt: []byte("0123456789abcdef")}, false},
+		{"uuid bytes length", "bt", UUID, pgtype.UUIDOID, &support.Supported{Bt: []byte("0123")}, true},
+		{"uuid invalid", "s", UUID, pgtype.UUIDOID, &support.Supported{S: "foo"}, true},
+		{"inet", "s", Inet, pgtype.InetOID, &support.Supported{S: "2001:db8::1"}, false},
+		{"inet network", "s", Inet, pgtype.InetOID, &support.Supported{S: "192.168.0.1/24"}, false},
+		{"cidr", "s", Inet, pgtype.CIDROID, &support.Supported{S: "192.168.0.0/24"}, false},
+		{"inet invalid", "s", Inet, pgtype.InetOID, &support.Supported{S: "foo"}, true},
+		{"macaddr", "s", Macaddr, pgtype.MacaddrOID, &support.Supported{S: "08:00:2b:01:02:03"}, false},
+		{"numeric", "s", Numeric, pgtype.NumericOID, &support.Supported{S: "-98765432109876543210.0123456789"}, false},
+		{"numeric NaN", "s", Numeric, pgtype.NumericOID, &support.Supported{S: "NaN"}, false},
+		{"numeric invalid", "s", Numeric, pgtype.NumericOID, &support.Supported{S: "1,5"}, true},
+		{"numeric exponent out of range", "s", Numeric, pgtype.NumericOID, &support.Supported{S: "1e200000000"}, true},
+		{"numeric exponent overflow", "s", Numeric, pgtype.NumericOID, &support.Supported{S: "1.5e-2147483648"}, true},
+		{"uuid list", "r_s", UUID, pgtype.UUIDArrayOID, &support.Supported{RS: []string{"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"}}, false},
+		{"uuid bytes list", "r_bt", UUID, pgtype.UUIDArrayOID, &support.Supported{RBt: [][]byte{[]byte("0123456789abcdef")}}, false},
+		{"numeric list", "r_s", Numeric, pgtype.NumericArrayOID, &support.Supported{RS: []string{"1.5", "0.000001"}}, false},
+		{"inet list", "r_s", Inet, pgtype.InetArrayOID, &support.Supported{RS: []string{"10.0.0.1", "10.0.0.0/8"}}, false},
+		{"macaddr bytes", "bt", Macaddr, 0, &support.Supported{Bt: []byte{1}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := (&support.Supported{}).ProtoReflect().Descriptor().Fields().ByName(tt.field)
+			opts := &Options{Encodings: map[string]Encoding{string(tt.field): tt.enc}}
+
+			v, err := opts.New(fd, pgtype.Present, string(tt.field), 0)
+			if err == nil {
+				err = v.SetFrom(tt.msg.ProtoReflect())
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Value.SetFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			// Round trip in binary format.
+			buf, err := v.EncodeBinary(connInfo, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w, err := new(Options).New(fd, pgtype.Undefined, string(tt.field), tt.oid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = w.DecodeBinary(connInfo, buf); err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(support.Supported)
+			if err = w.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, tt.msg) {
+				t.Errorf("round trip =\n%v\nwant\n%v", got, tt.msg)
+			}
+		})
+	}
+}
+
+func Test_newStringValue_empty(t *testing.T) {
+	fields := (&support.Supported{}).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		name  string
+		field pr.Name
+		enc   Encoding
+		want  interface{}
+	}{
+		{"uuid", "s", UUID, nil},
+		{"inet", "s", Inet, nil},
+		{"macaddr", "s", Macaddr, nil},
+		{"numeric", "s", Numeric, "0"},
+		{"list", "r_s", UUID, []interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newStringValue(fields.ByName(tt.field), pgtype.Present, tt.enc, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got interface{}
+			if buf, _ := v.EncodeText(connInfo, nil); buf != nil {
+				got = string(buf)
+			}
+			if l, ok := v.Get().([]interface{}); ok {
+				got = l
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newStringValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
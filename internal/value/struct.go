@@ -0,0 +1,44 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// newStructValue returns a Value for Struct, Value and ListValue fields,
+// which are stored as their JSON equivalent in json or jsonb columns.
+// The protojson options do not affect these types, so the defaults are used.
+func newStructValue(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) (Value, error) {
+	return new(Options).newJSONValue(fd, status, oid), nil
+}
+
+const (
+	SupportedStruct    = "google.protobuf.Struct"
+	SupportedValue     = "google.protobuf.Value"
+	SupportedListValue = "google.protobuf.ListValue"
+)
+
+func init() {
+	RegisterMessage(SupportedStruct, newStructValue)
+	RegisterMessage(SupportedValue, newStructValue)
+	RegisterMessage(SupportedListValue, newStructValue)
+}
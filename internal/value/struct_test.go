@@ -0,0 +1,106 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func Test_newStructValue(t *testing.T) {
+	st, err := structpb.NewStruct(map[string]interface{}{"foo": "bar", "n": 1, "l": []interface{}{true, nil}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		field string
+		json  string
+		want  *support.Supported
+	}{
+		{"st", `{"foo": "bar", "n": 1, "l": [true, null]}`, &support.Supported{St: st}},
+		{"val", `"foo"`, &support.Supported{Val: structpb.NewStringValue("foo")}},
+		{"val", `null`, &support.Supported{Val: structpb.NewNullValue()}},
+		{"lv", `[1, "a"]`, &support.Supported{Lv: &structpb.ListValue{Values: []*structpb.Value{structpb.NewNumberValue(1), structpb.NewStringValue("a")}}}},
+		{"r_st", `[{"foo": "bar", "n": 1, "l": [true, null]}, {}]`, &support.Supported{RSt: []*structpb.Struct{st, {}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			fd := new(support.Supported).ProtoReflect().Descriptor().Fields().ByName(pr.Name(tt.field))
+
+			for _, oid := range []uint32{0, pgtype.JSONOID, pgtype.JSONBOID} {
+				v, err := (*Options)(nil).New(fd, pgtype.Undefined, tt.field, oid)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err = v.Set([]byte(tt.json)); err != nil {
+					t.Fatal(err)
+				}
+
+				got := new(support.Supported)
+				if err = v.SetTo(got.ProtoReflect()); err != nil {
+					t.Fatal(err)
+				}
+				if !proto.Equal(got, tt.want) {
+					t.Errorf("SetTo =\n%v\nwant\n%v", got, tt.want)
+				}
+
+				if err = v.SetFrom(tt.want.ProtoReflect()); err != nil {
+					t.Fatal(err)
+				}
+				if !jsonEqual(t, v.(*jsonValue).json.Bytes, []byte(tt.json)) {
+					t.Errorf("SetFrom = %s, want %s", v.(*jsonValue).json.Bytes, tt.json)
+				}
+			}
+		})
+	}
+}
+
+func Test_newStructValue_zero(t *testing.T) {
+	fields := new(support.Supported).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"st", "{}"},
+		{"val", "null"},
+		{"lv", "[]"},
+		{"r_st", "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			v, err := newStructValue(fields.ByName(pr.Name(tt.field)), pgtype.Present, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := string(v.(*jsonValue).json.Bytes); got != tt.want {
+				t.Errorf("newStructValue = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
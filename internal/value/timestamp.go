@@ -20,6 +20,8 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package value
 
 import (
+	"time"
+
 	"github.com/jackc/pgtype"
 	pr "google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -32,10 +34,21 @@ type timestampValue struct {
 
 func (v *timestampValue) PGValue() pgtype.Value { return &v.Timestamptz }
 
-func (v *timestampValue) SetTo(msg pr.Message) {
+func (v *timestampValue) SetTo(msg pr.Message) error {
+	if v.Status != pgtype.Present {
+		return nil
+	}
+
 	msg.Set(v.fd, pr.ValueOfMessage(
 		timestamppb.New(v.Time).ProtoReflect(),
 	))
+
+	return nil
+}
+
+func (v *timestampValue) SetFrom(msg pr.Message) error {
+	ts := concreteMessage(msg.Get(v.fd).Message()).(*timestamppb.Timestamp)
+	return v.Set(ts.AsTime())
 }
 
 type timestampListValue struct {
@@ -45,7 +58,7 @@ type timestampListValue struct {
 
 func (v *timestampListValue) PGValue() pgtype.Value { return &v.TimestamptzArray }
 
-func (v *timestampListValue) SetTo(msg pr.Message) {
+func (v *timestampListValue) SetTo(msg pr.Message) error {
 	pl := msg.NewField(v.fd).List()
 
 	for _, x := range v.Elements {
@@ -55,9 +68,21 @@ func (v *timestampListValue) SetTo(msg pr.Message) {
 	}
 
 	msg.Set(v.fd, pr.ValueOfList(pl))
+	return nil
+}
+
+func (v *timestampListValue) SetFrom(msg pr.Message) error {
+	pl := msg.Get(v.fd).List()
+	times := make([]time.Time, pl.Len())
+
+	for i := range times {
+		times[i] = concreteMessage(pl.Get(i).Message()).(*timestamppb.Timestamp).AsTime()
+	}
+
+	return v.Set(times)
 }
 
-func newTimestampValue(fd pr.FieldDescriptor, status pgtype.Status) (Value, error) {
+func newTimestampValue(fd pr.FieldDescriptor, status pgtype.Status, _ uint32) (Value, error) {
 	if fd.IsList() {
 		return &timestampListValue{pgtype.TimestamptzArray{Status: status}, fd}, nil
 	}
@@ -0,0 +1,155 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// UnknownPolicy defines the handling of result columns which do not map to a field.
+type UnknownPolicy int
+
+const (
+	// ErrorUnknown returns an error for unknown columns.
+	// This is the default.
+	ErrorUnknown UnknownPolicy = iota
+
+	// IgnoreUnknown skips unknown columns.
+	IgnoreUnknown
+
+	// CollectUnknown sets unknown columns in the map<string, string>
+	// or google.protobuf.Struct field named by Options.UnknownField.
+	CollectUnknown
+)
+
+// unknownValue scans a column which does not map to a field,
+// and sets its text representation in a map<string, string>
+// or google.protobuf.Struct field, keyed by column name.
+// NULL values are omitted from maps and set as null Value in a Struct.
+type unknownValue struct {
+	pgtype.Value
+	fd     pr.FieldDescriptor
+	column string
+}
+
+// DecodeText decodes src using the underlying value,
+// as the column is of any type.
+func (v *unknownValue) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	d, ok := v.Value.(pgtype.TextDecoder)
+	if !ok {
+		return fmt.Errorf("value: column %s of type %T does not support text format", v.column, v.Value)
+	}
+
+	return d.DecodeText(ci, src)
+}
+
+// DecodeBinary decodes src using the underlying value,
+// as the column is of any type.
+func (v *unknownValue) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	d, ok := v.Value.(pgtype.BinaryDecoder)
+	if !ok {
+		return fmt.Errorf("value: column %s of type %T does not support binary format", v.column, v.Value)
+	}
+
+	return d.DecodeBinary(ci, src)
+}
+
+func (v *unknownValue) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	e, ok := v.Value.(pgtype.TextEncoder)
+	if !ok {
+		return nil, fmt.Errorf("value: column %s of type %T does not support text format", v.column, v.Value)
+	}
+
+	return e.EncodeText(ci, buf)
+}
+
+func (v *unknownValue) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	e, ok := v.Value.(pgtype.BinaryEncoder)
+	if !ok {
+		return nil, fmt.Errorf("value: column %s of type %T does not support binary format", v.column, v.Value)
+	}
+
+	return e.EncodeBinary(ci, buf)
+}
+
+func (v *unknownValue) PGValue() pgtype.Value { return v.Value }
+
+func (v *unknownValue) SetTo(msg pr.Message) error {
+	// The text representation is nil for NULL.
+	text, err := v.EncodeText(connInfo, nil)
+	if err != nil {
+		return err
+	}
+
+	if v.fd.IsMap() {
+		if text != nil {
+			msg.Mutable(v.fd).Map().Set(pr.ValueOfString(v.column).MapKey(), pr.ValueOfString(string(text)))
+		}
+		return nil
+	}
+
+	sv := structpb.NewNullValue()
+	if text != nil {
+		sv = structpb.NewStringValue(string(text))
+	}
+
+	fields := msg.Mutable(v.fd).Message()
+	fields.Mutable(fields.Descriptor().Fields().ByName("fields")).Map().Set(
+		pr.ValueOfString(v.column).MapKey(), pr.ValueOfMessage(sv.ProtoReflect()),
+	)
+	return nil
+}
+
+// SetFrom is not supported, unknown columns are only scanned.
+func (v *unknownValue) SetFrom(msg pr.Message) error {
+	return fmt.Errorf("value: column %s does not map to a field", v.column)
+}
+
+// NewUnknown returns a Value for a result column of type oid, which does not map to a field.
+// The value is set in the field named by UnknownField, which must be of type
+// map<string, string> or google.protobuf.Struct.
+func (o *Options) NewUnknown(fields pr.FieldDescriptors, column string, oid uint32) (Value, error) {
+	fd := fields.ByName(pr.Name(o.UnknownField))
+	switch {
+	case fd == nil:
+		return nil, fmt.Errorf("value: field %q for unknown columns not found", o.UnknownField)
+	case fd.IsMap():
+		if fd.MapKey().Kind() != pr.StringKind || fd.MapValue().Kind() != pr.StringKind {
+			return nil, fmt.Errorf("value: field %s for unknown columns must be map<string, string>", fd.FullName())
+		}
+	case fd.IsList() || fd.Message() == nil || fd.Message().FullName() != SupportedStruct:
+		return nil, fmt.Errorf("value: field %s for unknown columns must be map<string, string> or %s", fd.FullName(), SupportedStruct)
+	}
+
+	v := &unknownValue{fd: fd, column: column}
+
+	if dt, ok := connInfo.DataTypeForOID(oid); ok {
+		v.Value = pgtype.NewValue(dt.Value)
+	} else {
+		// Unknown types are scanned in text format.
+		v.Value = &pgtype.GenericText{}
+	}
+
+	return v, nil
+}
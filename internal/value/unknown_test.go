@@ -0,0 +1,101 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestOptions_NewUnknown_error(t *testing.T) {
+	fields := new(support.Supported).ProtoReflect().Descriptor().Fields()
+
+	for _, field := range []string{"foo", "mp", "ts_mp", "i32", "ts"} {
+		o := &Options{Unknown: CollectUnknown, UnknownField: field}
+		if _, err := o.NewUnknown(fields, "col", 0); err == nil {
+			t.Errorf("Options.NewUnknown(%s): expected error, got nil", field)
+		}
+	}
+}
+
+func TestOptions_NewUnknown(t *testing.T) {
+	type column struct {
+		name string
+		oid  uint32
+		src  []byte
+	}
+	columns := []column{
+		{"int", pgtype.Int4OID, []byte("12")},
+		{"ts", pgtype.TimestamptzOID, []byte("2022-01-07 13:47:07Z")},
+		{"null", pgtype.TextOID, nil},
+		{"other", 999999, []byte("foo")},
+	}
+
+	tests := []struct {
+		field string
+		want  *support.Supported
+	}{
+		{
+			"s_mp",
+			&support.Supported{SMp: map[string]string{
+				"int":   "12",
+				"ts":    "2022-01-07 13:47:07Z",
+				"other": "foo",
+			}},
+		},
+		{
+			"st",
+			&support.Supported{St: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"int":   structpb.NewStringValue("12"),
+				"ts":    structpb.NewStringValue("2022-01-07 13:47:07Z"),
+				"null":  structpb.NewNullValue(),
+				"other": structpb.NewStringValue("foo"),
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			o := &Options{Unknown: CollectUnknown, UnknownField: tt.field}
+			got := new(support.Supported)
+			msg := got.ProtoReflect()
+
+			for _, col := range columns {
+				v, err := o.NewUnknown(msg.Descriptor().Fields(), col.name, col.oid)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err = v.DecodeText(nil, col.src); err != nil {
+					t.Fatal(err)
+				}
+				if err = v.SetTo(msg); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("unknownValue.SetTo() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// is64BitUnsigned reports whether k is uint64 or fixed64.
+// Other unsigned kinds are 32 bit.
+func is64BitUnsigned(k pr.Kind) bool {
+	return k == pr.Uint64Kind || k == pr.Fixed64Kind
+}
+
+// isUnsignedKind reports whether k is any of the unsigned integer kinds.
+func isUnsignedKind(k pr.Kind) bool {
+	switch k {
+	case pr.Uint32Kind, pr.Fixed32Kind, pr.Uint64Kind, pr.Fixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+// unsignedEncoding returns the encoding for an unsigned field in a column of type oid.
+// Columns of the signed integer type of the same size use UnsignedCast.
+// Other known types, such as bigint for uint32 and numeric for uint64, use UnsignedWide.
+// If oid is 0 the default UnsignedCast is returned.
+func unsignedEncoding(fd pr.FieldDescriptor, oid uint32) Encoding {
+	if oid == 0 {
+		return UnsignedCast
+	}
+
+	if is64BitUnsigned(fd.Kind()) {
+		if oid == pgtype.Int8OID || oid == pgtype.Int8ArrayOID {
+			return UnsignedCast
+		}
+	} else if oid == pgtype.Int4OID || oid == pgtype.Int4ArrayOID {
+		return UnsignedCast
+	}
+
+	return UnsignedWide
+}
+
+// newUnsignedValue returns a Value for a (repeated) unsigned integer field.
+func newUnsignedValue(fd pr.FieldDescriptor, status pgtype.Status, enc Encoding, oid uint32) (v Value, err error) {
+	if enc == Auto {
+		enc = unsignedEncoding(fd, oid)
+	}
+
+	switch enc {
+	case UnsignedCast:
+		if fd.IsList() {
+			return newlistValue(fd, status, oid)
+		}
+		return newScalarValue(fd, status, oid)
+
+	case UnsignedWide:
+		return newWideUnsignedValue(fd, status, oid)
+
+	default:
+		return nil, fmt.Errorf("value: encoding %d not supported for unsigned field %s", enc, fd.FullName())
+	}
+}
+
+// newWideUnsignedValue returns a Value which stores uint32 in bigint
+// and uint64 in numeric columns, or their arrays.
+// Scanned values are range checked.
+func newWideUnsignedValue(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) (Value, error) {
+	vt, err := columnTranscoder(fd, status, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case fd.IsList() && is64BitUnsigned(fd.Kind()):
+		return &listValue[uint64]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.NumericArray{Status: status}), valueFunc: pr.ValueOfUint64}, nil
+	case fd.IsList():
+		return &listValue[uint32]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Int8Array{Status: status}), valueFunc: pr.ValueOfUint32}, nil
+	case is64BitUnsigned(fd.Kind()):
+		return &scalarValue[uint64]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Numeric{Status: status}), valueFunc: pr.ValueOfUint64}, nil
+	default:
+		return &scalarValue[uint32]{fd: fd, ValueTranscoder: orTranscoder(vt, &pgtype.Int8{Status: status}), valueFunc: pr.ValueOfUint32}, nil
+	}
+}
@@ -0,0 +1,140 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func Test_unsignedEncoding(t *testing.T) {
+	fields := new(support.Supported).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		field string
+		oid   uint32
+		want  Encoding
+	}{
+		{"u32", 0, UnsignedCast},
+		{"u32", pgtype.Int4OID, UnsignedCast},
+		{"u32", pgtype.Int8OID, UnsignedWide},
+		{"r_u32", pgtype.Int4ArrayOID, UnsignedCast},
+		{"r_u32", pgtype.Int8ArrayOID, UnsignedWide},
+		{"u64", 0, UnsignedCast},
+		{"u64", pgtype.Int8OID, UnsignedCast},
+		{"u64", pgtype.NumericOID, UnsignedWide},
+		{"r_u64", pgtype.NumericArrayOID, UnsignedWide},
+	}
+	for _, tt := range tests {
+		if got := unsignedEncoding(fields.ByName(pr.Name(tt.field)), tt.oid); got != tt.want {
+			t.Errorf("unsignedEncoding(%s, %d) = %v, want %v", tt.field, tt.oid, got, tt.want)
+		}
+	}
+}
+
+func Test_newUnsignedValue_wide(t *testing.T) {
+	want := &support.Supported{
+		U32:  math.MaxUint32,
+		U64:  math.MaxUint64,
+		RU32: []uint32{0, math.MaxUint32},
+		RU64: []uint64{0, math.MaxUint64},
+	}
+	fields := []pr.Name{"u32", "u64", "r_u32", "r_u64"}
+	src := want.ProtoReflect()
+
+	got := new(support.Supported)
+	dst := got.ProtoReflect()
+
+	for _, name := range fields {
+		fd := src.Descriptor().Fields().ByName(name)
+
+		v, err := newUnsignedValue(fd, pgtype.Null, UnsignedWide, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = v.SetFrom(src); err != nil {
+			t.Fatal(err)
+		}
+
+		// Round trip through the binary format, as used by the database.
+		buf, err := v.(pgtype.BinaryEncoder).EncodeBinary(connInfo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		v, err = newUnsignedValue(fd, pgtype.Undefined, UnsignedWide, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = v.(pgtype.BinaryDecoder).DecodeBinary(connInfo, buf); err != nil {
+			t.Fatal(err)
+		}
+		if err = v.SetTo(dst); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !proto.Equal(got, want) {
+		t.Errorf("newUnsignedValue() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func Test_newUnsignedValue_range(t *testing.T) {
+	fields := new(support.Supported).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		name  string
+		field pr.Name
+		oid   uint32
+		src   string
+	}{
+		{"negative", "u32", pgtype.Int8OID, "-1"},
+		{"overflow", "u32", pgtype.Int8OID, "4294967296"},
+		{"numeric overflow", "u64", pgtype.NumericOID, "18446744073709551616"},
+		{"array overflow", "r_u32", pgtype.Int8ArrayOID, "{1,4294967296}"},
+		{"array negative", "r_u64", pgtype.NumericArrayOID, "{-1}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newUnsignedValue(fields.ByName(tt.field), pgtype.Undefined, Auto, tt.oid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = v.(pgtype.TextDecoder).DecodeText(nil, []byte(tt.src)); err != nil {
+				t.Fatal(err)
+			}
+			if err = v.SetTo(new(support.Supported).ProtoReflect()); err == nil {
+				t.Error("Value.SetTo: expected error, got nil")
+			}
+		})
+	}
+}
+
+func Test_newUnsignedValue_error(t *testing.T) {
+	fd := new(support.Supported).ProtoReflect().Descriptor().Fields().ByName("u32")
+	if _, err := newUnsignedValue(fd, pgtype.Undefined, JSON, 0); err == nil {
+		t.Error("newUnsignedValue: expected error, got nil")
+	}
+}
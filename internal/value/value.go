@@ -21,15 +21,25 @@ package value
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 
 	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/pbpgxpb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
-type Constructor func(pr.FieldDescriptor, pgtype.Status) (Value, error)
+// Constructor returns a Value for the field, with the initial status.
+// The oid is the data type of the column, or 0 when unknown.
+type Constructor func(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) (Value, error)
 
 type register struct {
 	messages map[pr.FullName]Constructor
+	wrappers map[pr.FullName]bool // Built-in wrapper types, which are not overridden.
 }
 
 func (r *register) addMessage(fullname pr.FullName, c Constructor) {
@@ -38,18 +48,45 @@ func (r *register) addMessage(fullname pr.FullName, c Constructor) {
 	}
 
 	r.messages[fullname] = c
+	delete(r.wrappers, fullname)
 }
 
-func (r *register) newMessageValue(name pr.FullName, fd pr.FieldDescriptor, status pgtype.Status) (Value, error) {
-	if c, ok := r.messages[name]; ok {
-		return c(fd, status)
+func (r *register) addWrapper(fullname pr.FullName) {
+	r.addMessage(fullname, func(fd pr.FieldDescriptor, status pgtype.Status, oid uint32) (Value, error) {
+		return (*Options)(nil).newWrapperValue(fd, status, Auto, oid)
+	})
+
+	if r.wrappers == nil {
+		r.wrappers = map[pr.FullName]bool{}
 	}
+	r.wrappers[fullname] = true
+}
+
+func (r *register) isWrapper(name pr.FullName) bool {
+	return r.wrappers[name]
+}
 
-	return nil, fmt.Errorf("value: message type %q not registered", name)
+func (r *register) lookup(name pr.FullName) (Constructor, bool) {
+	c, ok := r.messages[name]
+	return c, ok
 }
 
 var registered register
 
+// concreteMessage returns m as its generated Go type, when registered in protoregistry.GlobalTypes.
+// Messages of other implementations, such as dynamicpb, are copied into a new message of the generated type,
+// so that converters of registered types can rely on type assertions.
+func concreteMessage(m pr.Message) proto.Message {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(m.Descriptor().FullName())
+	if err != nil || reflect.TypeOf(mt.Zero().Interface()) == reflect.TypeOf(m.Interface()) {
+		return m.Interface()
+	}
+
+	c := mt.New().Interface()
+	proto.Merge(c, m.Interface())
+	return c
+}
+
 func RegisterMessage(name pr.FullName, c Constructor) {
 	registered.addMessage(name, c)
 }
@@ -57,17 +94,303 @@ func RegisterMessage(name pr.FullName, c Constructor) {
 type Value interface {
 	pgtype.ValueTranscoder
 	PGValue() pgtype.Value
-	SetTo(pr.Message)
+
+	// SetTo sets the field in msg to the scanned value.
+	SetTo(msg pr.Message) error
+
+	// SetFrom sets the value from the field in msg, for use as query argument.
+	SetFrom(msg pr.Message) error
 }
 
-func New(fd pr.FieldDescriptor, status pgtype.Status) (v Value, err error) {
-	if msg := fd.Message(); msg != nil {
-		return registered.newMessageValue(msg.FullName(), fd, status)
+// Encoding of a field in a database column.
+// See the pbpgx package for the user-facing documentation of each Encoding.
+type Encoding int
+
+const (
+	Auto         Encoding = iota // Encoding based on the column's data type, or the field type's default.
+	EnumNumber                   // Enum values by number, in integer columns.
+	EnumName                     // Enum values by name, in text or enum columns.
+	JSON                         // protojson, in json or jsonb columns.
+	Hstore                       // map<string, string> in hstore columns.
+	Binary                       // Protocol buffers wire format, in bytea columns.
+	UnsignedCast                 // Unsigned integers in signed integer columns of the same size.
+	UnsignedWide                 // Unsigned integers in bigint or numeric columns, without loss.
+	Composite                    // Messages in composite type or record columns.
+	Array                        // Repeated row messages in multi-dimensional array columns.
+	UUID                         // String or bytes in uuid columns.
+	Inet                         // Strings in inet or cidr columns.
+	Macaddr                      // Strings in macaddr columns.
+	Numeric                      // Decimal strings in numeric columns.
+)
+
+// Option modifies Options.
+// The function is unexported, so that Options can only be modified by the options of package pbpgx.
+type Option struct {
+	apply func(*Options)
+}
+
+// NewOption returns an Option which applies f.
+func NewOption(f func(*Options)) Option {
+	return Option{f}
+}
+
+// NewOptions returns Options with opts applied in order.
+func NewOptions(opts []Option) *Options {
+	o := new(Options)
+	for _, opt := range opts {
+		if opt.apply != nil {
+			opt.apply(o)
+		}
+	}
+
+	return o
+}
+
+// Options for the creation of Values.
+// The zero value and nil are valid and result in the default (Auto) behaviour.
+// Options must not be modified after first use.
+type Options struct {
+	Encodings map[string]Encoding // Encoding per column name.
+
+	// MessageEncoding is used for message fields of types which are not registered,
+	// when no Encoding is set for the column.
+	// Auto results in an error for such fields.
+	MessageEncoding Encoding
+
+	// UnsignedEncoding is used for unsigned integer fields,
+	// when no Encoding is set for the column.
+	UnsignedEncoding Encoding
+
+	JSONMarshal   protojson.MarshalOptions   // Used for JSON encoding.
+	JSONUnmarshal protojson.UnmarshalOptions // Used for JSON decoding.
+
+	// Naming returns the column name of fields without the (pbpgx.column) option.
+	// If nil, the field name is used.
+	Naming func(fd pr.FieldDescriptor) string
+
+	// FoldCase matches column names to fields case-insensitively.
+	FoldCase bool
+
+	// Unknown is the policy for result columns which do not map to a field.
+	Unknown UnknownPolicy
+
+	// UnknownField is the name of the field in which unknown columns
+	// are collected, with the CollectUnknown policy.
+	UnknownField string
+
+	// OneofFirstWins keeps the first scanned member of a oneof,
+	// when the columns of more than one member are not NULL.
+	// By default this results in an error.
+	OneofFirstWins bool
+
+	// NullMask is the name of a google.protobuf.FieldMask field,
+	// in which the paths of fields scanned from NULL columns are set.
+	NullMask string
+
+	// NullElements is the policy for NULL elements in arrays,
+	// scanned into repeated fields.
+	NullElements NullElementPolicy
+
+	mu      sync.Mutex
+	columns map[pr.FieldDescriptors]map[string]pr.FieldDescriptor // Field index per fields, by column name.
+}
+
+func (o *Options) encoding(column string) Encoding {
+	if o == nil {
+		return Auto
+	}
+
+	return o.Encodings[column]
+}
+
+// Column returns the column name of the field.
+// The (pbpgx.column) option takes precedence over the Naming function.
+func (o *Options) Column(fd pr.FieldDescriptor) string {
+	if o == nil || o.Naming == nil || proto.HasExtension(fd.Options(), pbpgxpb.E_Column) {
+		return pbpgxpb.ColumnName(fd)
+	}
+
+	return o.Naming(fd)
+}
+
+// Field returns the field from fields which maps to the named column,
+// or nil if there is none.
+// The column names of fields are indexed on first use.
+func (o *Options) Field(fields pr.FieldDescriptors, column string) pr.FieldDescriptor {
+	if o == nil {
+		return newFieldIndex(nil, fields, false)[column]
+	}
+
+	if o.FoldCase {
+		column = strings.ToLower(column)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	index, ok := o.columns[fields]
+	if !ok {
+		if o.columns == nil {
+			o.columns = make(map[pr.FieldDescriptors]map[string]pr.FieldDescriptor)
+		}
+		index = newFieldIndex(o, fields, o.FoldCase)
+		o.columns[fields] = index
+	}
+
+	return index[column]
+}
+
+// newFieldIndex maps the column names of fields to the fields.
+// If foldCase is true, column names are lower cased.
+// When more than one field maps to a column, the first field wins.
+func newFieldIndex(o *Options, fields pr.FieldDescriptors, foldCase bool) map[string]pr.FieldDescriptor {
+	index := make(map[string]pr.FieldDescriptor, fields.Len())
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := o.Column(fd)
+		if foldCase {
+			name = strings.ToLower(name)
+		}
+
+		if _, ok := index[name]; !ok {
+			index[name] = fd
+		}
+	}
+
+	return index
+}
+
+// New returns a Value for the field, scanned from or written to the named column.
+// The oid is the data type of the column, or 0 when unknown.
+// Repeated fields, scanned from array columns, apply the NullElements policy.
+func (o *Options) New(fd pr.FieldDescriptor, status pgtype.Status, column string, oid uint32) (v Value, err error) {
+	if o == nil {
+		o = new(Options)
+	}
+
+	if v, err = o.newValue(fd, status, column, oid); err != nil {
+		return nil, err
+	}
+
+	if _, ok := v.(*arrayValue); status == pgtype.Undefined && fd.IsList() && !ok && isArrayValue(v) {
+		v = &nullElementsValue{v, fd, o.NullElements}
+	}
+
+	return v, nil
+}
+
+func (o *Options) newValue(fd pr.FieldDescriptor, status pgtype.Status, column string, oid uint32) (v Value, err error) {
+	// Unset optional fields and oneof members are always NULL,
+	// regardless of the status requested for empty fields.
+	if (fd.HasOptionalKeyword() || fd.ContainingOneof() != nil) && status == pgtype.Present {
+		status = pgtype.Null
+	}
+
+	if fd.IsMap() {
+		return o.newMapValue(fd, status, o.encoding(column), oid)
+	}
+
+	if fd.Message() != nil {
+		return o.newMessageValue(fd, status, o.encoding(column), oid)
+	}
+
+	if fd.Kind() == pr.EnumKind {
+		return newEnumValue(fd, status, o.encoding(column), oid)
+	}
+
+	if isUnsignedKind(fd.Kind()) {
+		enc := o.encoding(column)
+		if enc == Auto {
+			enc = o.UnsignedEncoding
+		}
+		return newUnsignedValue(fd, status, enc, oid)
+	}
+
+	if fd.Kind() == pr.StringKind || fd.Kind() == pr.BytesKind {
+		if enc := stringEncoding(o.encoding(column), oid); enc != Auto {
+			return newStringValue(fd, status, enc, oid)
+		}
 	}
 
 	if fd.IsList() {
-		return newlistValue(fd, status)
+		return newlistValue(fd, status, oid)
 	}
 
-	return newScalarValue(fd, status)
+	return newScalarValue(fd, status, oid)
+}
+
+func (o *Options) newMessageValue(fd pr.FieldDescriptor, status pgtype.Status, enc Encoding, oid uint32) (Value, error) {
+	name := fd.Message().FullName()
+
+	// Wrappers of unsigned integers apply the unsigned encodings.
+	if registered.isWrapper(name) && (enc == Auto || enc == UnsignedCast || enc == UnsignedWide) {
+		return o.newWrapperValue(fd, status, enc, oid)
+	}
+
+	if enc == Auto {
+		if c, ok := registered.lookup(name); ok {
+			return c(fd, status, oid)
+		}
+		if isRange(fd.Message()) {
+			return o.newRangeValue(fd, status, oid)
+		}
+
+		enc = o.messageEncoding(fd, oid)
+	}
+
+	switch enc {
+	case Auto:
+		return nil, fmt.Errorf("value: message type %q not registered", name)
+
+	case JSON:
+		return o.newJSONValue(fd, status, oid), nil
+
+	case Binary:
+		return newBinaryValue(fd, status), nil
+
+	case Composite:
+		return o.newCompositeValue(fd, status, oid), nil
+
+	case Array:
+		return o.newArrayValue(fd, status, oid)
+
+	default:
+		return nil, fmt.Errorf("value: encoding %d not supported for message field %s", enc, fd.FullName())
+	}
+}
+
+// messageEncoding returns the encoding for message fields of types which are not registered,
+// in a column of type oid.
+// Record columns use Composite and json or jsonb columns use JSON, regardless of the MessageEncoding.
+// Types unknown to pgtype, such as user defined composite types, use Composite
+// when the MessageEncoding is Auto.
+// Repeated fields of array rows use Array in array columns other than bytea[],
+// or when the MessageEncoding is Auto.
+// Other columns use the MessageEncoding.
+func (o *Options) messageEncoding(fd pr.FieldDescriptor, oid uint32) Encoding {
+	switch oid {
+	case pgtype.RecordOID, recordArrayOID:
+		return Composite
+	case pgtype.JSONOID, pgtype.JSONBOID:
+		return JSON
+	}
+
+	if o.MessageEncoding == Auto && oid != 0 {
+		if _, ok := connInfo.DataTypeForOID(oid); !ok {
+			return Composite
+		}
+	}
+
+	if fd.IsList() && isArrayRow(fd.Message()) &&
+		(o.MessageEncoding == Auto || isArrayOID(oid) && oid != pgtype.ByteaArrayOID) {
+		return Array
+	}
+
+	return o.MessageEncoding
+}
+
+// New returns a Value for the field, using default Options.
+func New(fd pr.FieldDescriptor, status pgtype.Status) (v Value, err error) {
+	return (*Options)(nil).New(fd, status, string(fd.Name()), 0)
 }
@@ -20,10 +20,17 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package value
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgtype"
 	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Lazy test for coverage and nil error only.
@@ -57,3 +64,176 @@ func TestNew_error(t *testing.T) {
 		dest[i] = d
 	}
 }
+
+func TestOptions_newMessageValue(t *testing.T) {
+	sup := new(support.Unsupported).ProtoReflect().Descriptor().Fields().ByName("sup")
+	ts := new(support.Supported).ProtoReflect().Descriptor().Fields().ByName("ts")
+
+	tests := []struct {
+		name    string
+		opts    *Options
+		fd      pr.FieldDescriptor
+		enc     Encoding
+		want    Value
+		wantErr bool
+	}{
+		{
+			"registered",
+			&Options{},
+			ts,
+			Auto,
+			&timestampValue{pgtype.Timestamptz{Status: pgtype.Null}, ts},
+			false,
+		},
+		{
+			"registered as JSON",
+			&Options{},
+			ts,
+			JSON,
+			new(Options).newJSONValue(ts, pgtype.Null, 0),
+			false,
+		},
+		{
+			"not registered error",
+			&Options{},
+			sup,
+			Auto,
+			nil,
+			true,
+		},
+		{
+			"not registered, JSON",
+			&Options{},
+			sup,
+			JSON,
+			new(Options).newJSONValue(sup, pgtype.Null, 0),
+			false,
+		},
+		{
+			"not registered, MessageEncoding JSON",
+			&Options{
+				MessageEncoding: JSON,
+				JSONMarshal:     protojson.MarshalOptions{UseProtoNames: true},
+			},
+			sup,
+			Auto,
+			(&Options{JSONMarshal: protojson.MarshalOptions{UseProtoNames: true}}).newJSONValue(sup, pgtype.Null, 0),
+			false,
+		},
+		{
+			"not registered, Binary",
+			&Options{},
+			sup,
+			Binary,
+			&binaryValue{pgtype.Bytea{Status: pgtype.Null}, sup},
+			false,
+		},
+		{
+			"not registered, MessageEncoding Binary",
+			&Options{MessageEncoding: Binary},
+			sup,
+			Auto,
+			&binaryValue{pgtype.Bytea{Status: pgtype.Null}, sup},
+			false,
+		},
+		{
+			"unsupported encoding error",
+			&Options{},
+			sup,
+			Hstore,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.newMessageValue(tt.fd, pgtype.Null, tt.enc, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Options.newMessageValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Options.newMessageValue() =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_optional(t *testing.T) {
+	fields := new(support.Supported).ProtoReflect().Descriptor().Fields()
+
+	for _, name := range []pr.Name{"o_i32", "o_s", "o_en", "o_ts"} {
+		t.Run(string(name), func(t *testing.T) {
+			v, err := New(fields.ByName(name), pgtype.Present)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := v.Get(); got != nil {
+				t.Errorf("New() Get = %v, want nil", got)
+			}
+
+			msg := new(support.Supported).ProtoReflect()
+			if err = v.SetTo(msg); err != nil {
+				t.Fatal(err)
+			}
+			if msg.Has(fields.ByName(name)) {
+				t.Errorf("SetTo: field %s set, want unset", name)
+			}
+		})
+	}
+}
+
+func Test_concreteMessage(t *testing.T) {
+	ts := timestamppb.New(time.Unix(12, 34))
+	if got := concreteMessage(ts.ProtoReflect()); got != ts {
+		t.Errorf("concreteMessage() = %p, want %p", got, ts)
+	}
+
+	dyn := dynamicpb.NewMessage(ts.ProtoReflect().Descriptor())
+	proto.Merge(dyn, ts)
+
+	got, ok := concreteMessage(dyn).(*timestamppb.Timestamp)
+	if !ok || !proto.Equal(got, ts) {
+		t.Errorf("concreteMessage() = %T %v, want %T %v", got, got, ts, ts)
+	}
+}
+
+func TestOptions_Field(t *testing.T) {
+	fields := new(support.Legacy).ProtoReflect().Descriptor().Fields()
+	prefix := func(fd pr.FieldDescriptor) string { return "X_" + string(fd.Name()) }
+
+	tests := []struct {
+		name   string
+		o      *Options
+		column string
+		want   pr.Name
+	}{
+		{"nil options", nil, "legacy_id", "id"},
+		{"column option", &Options{}, "legacy_title", "title"},
+		{"field name", &Options{}, "data", "data"},
+		{"field name with column option", &Options{}, "id", ""},
+		{"naming", &Options{Naming: prefix}, "X_data", "data"},
+		{"naming with column option", &Options{Naming: prefix}, "legacy_id", "id"},
+		{"case sensitive", &Options{}, "Legacy_ID", ""},
+		{"fold case", &Options{FoldCase: true}, "Legacy_ID", "id"},
+		{"unknown", &Options{}, "foo", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Second lookup uses the index.
+			for i := 0; i < 2; i++ {
+				var got pr.Name
+				if fd := tt.o.Field(fields, tt.column); fd != nil {
+					got = fd.Name()
+				}
+				if got != tt.want {
+					t.Errorf("Options.Field() = %q, want %q", got, tt.want)
+				}
+			}
+			if tt.o != nil && len(tt.o.columns) != 1 {
+				t.Errorf("Options.Field() indexed %d field sets, want 1", len(tt.o.columns))
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// wrapperValue scans and writes google.protobuf wrapper messages,
+// such as Int64Value, from and to nullable columns of the wrapped scalar type.
+// SQL NULL corresponds to an unset wrapper field.
+type wrapperValue struct {
+	Value // Scalar Value of the wrapped "value" field.
+	fd    pr.FieldDescriptor
+}
+
+func (v *wrapperValue) SetTo(msg pr.Message) error {
+	switch v.Get().(type) {
+	case nil, pgtype.Status:
+		// NULL or undefined leaves the wrapper unset.
+		return nil
+	}
+
+	wrapper := msg.NewField(v.fd).Message()
+	if err := v.Value.SetTo(wrapper); err != nil {
+		return err
+	}
+
+	msg.Set(v.fd, pr.ValueOfMessage(wrapper))
+	return nil
+}
+
+func (v *wrapperValue) SetFrom(msg pr.Message) error {
+	return v.Value.SetFrom(msg.Get(v.fd).Message())
+}
+
+// newWrapperValue returns a Value for a wrapper field.
+// Wrapped unsigned integers use enc, or the UnsignedEncoding when enc is Auto.
+func (o *Options) newWrapperValue(fd pr.FieldDescriptor, status pgtype.Status, enc Encoding, oid uint32) (Value, error) {
+	if fd.IsList() {
+		return nil, fmt.Errorf("value: repeated wrapper type %s not supported", fd.Message().FullName())
+	}
+
+	// An unset wrapper always means NULL,
+	// regardless of the status requested for empty fields.
+	if status == pgtype.Present {
+		status = pgtype.Null
+	}
+
+	var (
+		vfd = fd.Message().Fields().ByName("value")
+		v   Value
+		err error
+	)
+	if isUnsignedKind(vfd.Kind()) {
+		if enc == Auto && o != nil {
+			enc = o.UnsignedEncoding
+		}
+		v, err = newUnsignedValue(vfd, status, enc, oid)
+	} else {
+		v, err = newScalarValue(vfd, status, oid)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrapperValue{v, fd}, nil
+}
+
+const (
+	SupportedBoolValue   = "google.protobuf.BoolValue"
+	SupportedInt32Value  = "google.protobuf.Int32Value"
+	SupportedInt64Value  = "google.protobuf.Int64Value"
+	SupportedFloatValue  = "google.protobuf.FloatValue"
+	SupportedDoubleValue = "google.protobuf.DoubleValue"
+	SupportedStringValue = "google.protobuf.StringValue"
+	SupportedBytesValue  = "google.protobuf.BytesValue"
+	SupportedUInt32Value = "google.protobuf.UInt32Value"
+	SupportedUInt64Value = "google.protobuf.UInt64Value"
+)
+
+func init() {
+	for _, name := range []pr.FullName{
+		SupportedBoolValue,
+		SupportedInt32Value,
+		SupportedInt64Value,
+		SupportedFloatValue,
+		SupportedDoubleValue,
+		SupportedStringValue,
+		SupportedBytesValue,
+		SupportedUInt32Value,
+		SupportedUInt64Value,
+	} {
+		registered.addWrapper(name)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package value
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_wrapperValue(t *testing.T) {
+	tests := []struct {
+		field string
+		value interface{}
+		want  *support.Supported
+	}{
+		{"w_bl", true, &support.Supported{WBl: wrapperspb.Bool(true)}},
+		{"w_bl", false, &support.Supported{WBl: wrapperspb.Bool(false)}},
+		{"w_i32", int32(1), &support.Supported{WI32: wrapperspb.Int32(1)}},
+		{"w_i64", int64(0), &support.Supported{WI64: wrapperspb.Int64(0)}},
+		{"w_f", float32(1.5), &support.Supported{WF: wrapperspb.Float(1.5)}},
+		{"w_d", float64(2.5), &support.Supported{WD: wrapperspb.Double(2.5)}},
+		{"w_s", "", &support.Supported{WS: wrapperspb.String("")}},
+		{"w_bt", []byte("foo"), &support.Supported{WBt: wrapperspb.Bytes([]byte("foo"))}},
+		{"w_u32", uint32(32), &support.Supported{WU32: wrapperspb.UInt32(32)}},
+		{"w_u64", uint64(64), &support.Supported{WU64: wrapperspb.UInt64(64)}},
+		{"w_i64", nil, &support.Supported{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			fd := new(support.Supported).ProtoReflect().Descriptor().Fields().ByName(pr.Name(tt.field))
+
+			v, err := New(fd, pgtype.Undefined)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = v.Set(tt.value); err != nil {
+				t.Fatal(err)
+			}
+
+			got := new(support.Supported)
+			if err = v.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("wrapperValue.SetTo =\n%v\nwant\n%v", got, tt.want)
+			}
+
+			w, err := New(fd, pgtype.Present)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.ProtoReflect().Has(fd) {
+				if err = w.SetFrom(got.ProtoReflect()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Present status is overridden to Null, so unset wrappers result in nil.
+			if got, want := w.Get(), v.Get(); !reflect.DeepEqual(got, want) {
+				t.Errorf("wrapperValue.SetFrom Get = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_newWrapperValue_undefined(t *testing.T) {
+	fd := new(support.Supported).ProtoReflect().Descriptor().Fields().ByName("w_s")
+
+	v, err := (*Options)(nil).newWrapperValue(fd, pgtype.Undefined, Auto, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := new(support.Supported)
+	if err = v.SetTo(got.ProtoReflect()); err != nil {
+		t.Fatal(err)
+	}
+	if got.WS != nil {
+		t.Errorf("wrapperValue.SetTo = %v, want nil", got.WS)
+	}
+}
+
+func Test_wrapperValue_unsignedWide(t *testing.T) {
+	fd := new(support.Supported).ProtoReflect().Descriptor().Fields().ByName("w_u64")
+	want := &support.Supported{WU64: wrapperspb.UInt64(math.MaxUint64)}
+
+	tests := []struct {
+		name string
+		o    *Options
+	}{
+		{"unsigned encoding", &Options{UnsignedEncoding: UnsignedWide}},
+		{"column encoding", &Options{Encodings: map[string]Encoding{"w_u64": UnsignedWide}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := tt.o.New(fd, pgtype.Present, "w_u64", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = w.SetFrom(want.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			n, ok := w.PGValue().(*pgtype.Numeric)
+			if !ok {
+				t.Fatalf("wrapperValue.PGValue = %T, want %T", w.PGValue(), n)
+			}
+
+			v, err := tt.o.New(fd, pgtype.Undefined, "w_u64", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf, err := n.EncodeBinary(nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = v.DecodeBinary(nil, buf); err != nil {
+				t.Fatal(err)
+			}
+			got := new(support.Supported)
+			if err = v.SetTo(got.ProtoReflect()); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("wrapperValue.SetTo =\n%v\nwant\n%v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/muhlemmer/pbpgx/internal/value"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NamingStrategy returns the column name for a field.
+// It is used for fields without the (pbpgx.column) option.
+type NamingStrategy func(fd pr.FieldDescriptor) string
+
+// ProtoName returns the field name, as defined in the proto file.
+// This is the default NamingStrategy.
+func ProtoName(fd pr.FieldDescriptor) string {
+	return string(fd.Name())
+}
+
+// JSONName returns the JSON name of the field.
+// This is the json_name option, if set,
+// or otherwise the lowerCamelCase form of the field name.
+func JSONName(fd pr.FieldDescriptor) string {
+	return fd.JSONName()
+}
+
+// SnakeCase returns the snake_case form of the field name,
+// for fields named in (lower)CamelCase.
+// For example "createdAt" becomes "created_at" and "userID" becomes "user_id".
+func SnakeCase(fd pr.FieldDescriptor) string {
+	return snakeCase(string(fd.Name()))
+}
+
+// LowerCamelCase returns the lowerCamelCase form of the field name,
+// for fields named in snake_case.
+// For example "created_at" becomes "createdAt".
+// Unlike JSONName, the json_name option is not used.
+func LowerCamelCase(fd pr.FieldDescriptor) string {
+	return lowerCamelCase(string(fd.Name()))
+}
+
+// FieldNumber returns the field number, prefixed with "f".
+// For example field number 12 becomes "f12".
+// Column names remain stable when fields are renamed,
+// as long as field numbers are not reused.
+func FieldNumber(fd pr.FieldDescriptor) string {
+	return fmt.Sprintf("f%d", fd.Number())
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 4)
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word, unless this is a continuation of an acronym.
+			if i > 0 && runes[i-1] != '_' && (!unicode.IsUpper(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func lowerCamelCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	upper := false
+	for _, r := range s {
+		if r == '_' {
+			upper = b.Len() > 0
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// WithNaming sets the NamingStrategy, used to match columns to fields.
+// The (pbpgx.column) field option takes precedence over the strategy.
+func WithNaming(ns NamingStrategy) Option {
+	return value.NewOption(func(o *value.Options) {
+		o.Naming = ns
+	})
+}
+
+// WithCaseInsensitive matches column names to fields case-insensitively,
+// for example when scanning from unquoted identifiers, which PostgreSQL folds to lower case.
+// Names written to queries, such as returned by crud.Table.ParseFields, are not affected.
+func WithCaseInsensitive() Option {
+	return value.NewOption(func(o *value.Options) {
+		o.FoldCase = true
+	})
+}
@@ -0,0 +1,150 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_snakeCase(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"id", "id"},
+		{"createdAt", "created_at"},
+		{"CreatedAt", "created_at"},
+		{"userID", "user_id"},
+		{"HTTPServer", "http_server"},
+		{"address2Line", "address2_line"},
+		{"already_snake", "already_snake"},
+	}
+	for _, tt := range tests {
+		if got := snakeCase(tt.s); got != tt.want {
+			t.Errorf("snakeCase(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func Test_lowerCamelCase(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"id", "id"},
+		{"created_at", "createdAt"},
+		{"r_i32", "rI32"},
+		{"_private", "private"},
+		{"alreadyCamel", "alreadyCamel"},
+	}
+	for _, tt := range tests {
+		if got := lowerCamelCase(tt.s); got != tt.want {
+			t.Errorf("lowerCamelCase(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestNamingStrategy(t *testing.T) {
+	fd := new(support.Supported).ProtoReflect().Descriptor().Fields().ByName("o_i32")
+
+	tests := []struct {
+		name string
+		ns   NamingStrategy
+		want string
+	}{
+		{"ProtoName", ProtoName, "o_i32"},
+		{"JSONName", JSONName, "oI32"},
+		{"SnakeCase", SnakeCase, "o_i32"},
+		{"LowerCamelCase", LowerCamelCase, "oI32"},
+		{"FieldNumber", FieldNumber, "f53"},
+	}
+	for _, tt := range tests {
+		if got := tt.ns(fd); got != tt.want {
+			t.Errorf("%s() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScan_naming(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		opts    []Option
+		want    *support.Supported
+		wantErr bool
+	}{
+		{
+			"default",
+			[]string{"o_i32", "w_s"},
+			nil,
+			&support.Supported{OI32: proto.Int32(1), WS: wrapperspb.String("foo")},
+			false,
+		},
+		{
+			"default, camel case columns",
+			[]string{"oI32", "wS"},
+			nil,
+			nil,
+			true,
+		},
+		{
+			"lower camel case",
+			[]string{"oI32", "wS"},
+			[]Option{WithNaming(LowerCamelCase)},
+			&support.Supported{OI32: proto.Int32(1), WS: wrapperspb.String("foo")},
+			false,
+		},
+		{
+			"case insensitive",
+			[]string{"O_I32", "W_s"},
+			[]Option{WithCaseInsensitive()},
+			&support.Supported{OI32: proto.Int32(1), WS: wrapperspb.String("foo")},
+			false,
+		},
+		{
+			"custom",
+			[]string{"f53", "f33"},
+			[]Option{WithNaming(func(fd pr.FieldDescriptor) string {
+				return fmt.Sprintf("f%d", fd.Number())
+			})},
+			&support.Supported{OI32: proto.Int32(1), WS: wrapperspb.String("foo")},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := newTestRows(tt.names, [][]interface{}{{int32(1), "foo"}})
+
+			got, err := ScanOne[*support.Supported](rows, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ScanOne() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("ScanOne() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"strings"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/value"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// nestedSeparators separate the names of nested message fields in column names,
+// such as "author.name" or "author__name".
+var nestedSeparators = []string{".", "__"}
+
+// nestedField returns the field a column maps to, and the path of message fields leading to it.
+// Columns which map to a field of fields directly have an empty path.
+// Otherwise, column names are split at each separator and the prefix is matched
+// to a singular message field. The remainder is resolved recursively in that message.
+// If lists is true, the prefix may also match a repeated message field of fields,
+// which must then be the first element of path.
+// Nil is returned if the column does not map to a field.
+func nestedField(fields pr.FieldDescriptors, column string, opts *value.Options, lists bool) (path []pr.FieldDescriptor, fd pr.FieldDescriptor) {
+	if fd = opts.Field(fields, column); fd != nil {
+		return nil, fd
+	}
+
+	for _, sep := range nestedSeparators {
+		for i := strings.Index(column, sep); i > 0; {
+			pfd := opts.Field(fields, column[:i])
+
+			if pfd != nil && pfd.Message() != nil && !pfd.IsMap() && (lists || !pfd.IsList()) {
+				if path, fd = nestedField(pfd.Message().Fields(), column[i+len(sep):], opts, false); fd != nil {
+					return append([]pr.FieldDescriptor{pfd}, path...), fd
+				}
+			}
+
+			next := strings.Index(column[i+len(sep):], sep)
+			if next < 0 {
+				break
+			}
+			i += len(sep) + next
+		}
+	}
+
+	return nil, nil
+}
+
+// nestedValue sets a Value in a nested message, which is reached through path.
+// Nested messages are only created for non-NULL values,
+// so that a nested message remains unset when all its columns are NULL.
+type nestedValue struct {
+	value.Value
+	path []pr.FieldDescriptor
+}
+
+// isNull reports whether v holds a NULL or undefined value.
+func isNull(v value.Value) bool {
+	switch v.Get().(type) {
+	case nil, pgtype.Status:
+		return true
+	default:
+		return false
+	}
+}
+
+func (v *nestedValue) SetTo(msg pr.Message) error {
+	if isNull(v.Value) {
+		return nil
+	}
+
+	for _, fd := range v.path {
+		msg = msg.Mutable(fd).Message()
+	}
+
+	return v.Value.SetTo(msg)
+}
@@ -0,0 +1,100 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_nestedField(t *testing.T) {
+	fields := new(support.Unsupported).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		column   string
+		wantPath []pr.Name
+		wantFd   pr.Name
+	}{
+		{"sup", nil, "sup"},
+		{"sup.i32", []pr.Name{"sup"}, "i32"},
+		{"sup__r_i32", []pr.Name{"sup"}, "r_i32"},
+		{"sup.ts.seconds", []pr.Name{"sup", "ts"}, "seconds"},
+		{"sup__ts__nanos", []pr.Name{"sup", "ts"}, "nanos"},
+		{"sup.foo", nil, ""},
+		{"sup.r_w_i64.value", nil, ""},
+		{"r_w_i64.value", nil, ""},
+		{".sup", nil, ""},
+		{"sup.", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+			path, fd := nestedField(fields, tt.column, nil, false)
+
+			var gotPath []pr.Name
+			for _, pfd := range path {
+				gotPath = append(gotPath, pfd.Name())
+			}
+			var gotFd pr.Name
+			if fd != nil {
+				gotFd = fd.Name()
+			}
+
+			if !reflect.DeepEqual(gotPath, tt.wantPath) || gotFd != tt.wantFd {
+				t.Errorf("nestedField() = %v, %q, want %v, %q", gotPath, gotFd, tt.wantPath, tt.wantFd)
+			}
+		})
+	}
+}
+
+func TestScan_nested(t *testing.T) {
+	rows := newTestRows(
+		[]string{"sup.i32", "sup__s", "sup.ts.seconds"},
+		[][]interface{}{
+			{int32(1), "foo", int64(5)},
+			{nil, nil, nil},
+			{nil, "", nil},
+		},
+	)
+
+	got, err := Scan[*support.Unsupported](rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*support.Unsupported{
+		{Sup: &support.Supported{I32: 1, S: "foo", Ts: &timestamppb.Timestamp{Seconds: 5}}},
+		{},
+		{Sup: &support.Supported{}},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Scan() =\n%v\nwant\n%v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("Scan()[%d] =\n%v\nwant\n%v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,151 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgx/v4"
+	"github.com/muhlemmer/pbpgx/internal/value"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// NullSet holds the paths of the fields of a scanned message, of which the column was NULL.
+// Paths consist of proto field names, separated by dots for fields of nested messages,
+// as in google.protobuf.FieldMask.
+type NullSet map[string]struct{}
+
+// Has reports whether the field at path was scanned from a NULL column.
+func (s NullSet) Has(path string) bool {
+	_, ok := s[path]
+	return ok
+}
+
+// Paths returns the sorted paths in the set.
+func (s NullSet) Paths() []string {
+	paths := make([]string, 0, len(s))
+	for path := range s {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+	return paths
+}
+
+// fieldPaths returns the path of the field each column maps to,
+// or an empty string for columns which do not map to a field.
+func fieldPaths(md pr.MessageDescriptor, pgfs []pgproto3.FieldDescription, opts *value.Options) []string {
+	paths := make([]string, len(pgfs))
+
+	for i, f := range pgfs {
+		path, fd := nestedField(md.Fields(), string(f.Name), opts, false)
+		if fd == nil {
+			continue
+		}
+
+		names := make([]string, 0, len(path)+1)
+		for _, pfd := range path {
+			names = append(names, string(pfd.Name()))
+		}
+		paths[i] = strings.Join(append(names, string(fd.Name())), ".")
+	}
+
+	return paths
+}
+
+// nullMaskField returns the field of md with the name,
+// which must be of type google.protobuf.FieldMask.
+func nullMaskField(md pr.MessageDescriptor, name string) (pr.FieldDescriptor, error) {
+	fd := md.Fields().ByName(pr.Name(name))
+	if fd == nil || fd.IsList() || fd.Message() == nil || fd.Message().FullName() != "google.protobuf.FieldMask" {
+		return nil, fmt.Errorf("null mask field %s of %s must be of type google.protobuf.FieldMask", name, md.FullName())
+	}
+
+	return fd, nil
+}
+
+// trackNulls enables tracking of NULL columns for each scanned row.
+func (s *scanner[M]) trackNulls(opts *value.Options) error {
+	s.paths = fieldPaths(s.msg.Descriptor(), s.rows.FieldDescriptions(), opts)
+
+	if opts.NullMask != "" {
+		fd, err := nullMaskField(s.msg.Descriptor(), opts.NullMask)
+		if err != nil {
+			return err
+		}
+		s.nullMask = fd
+	}
+
+	return nil
+}
+
+// setNulls sets the NullSet of the scanned row, and the null mask field in msg.
+func (s *scanner[M]) setNulls(msg pr.Message) {
+	s.nulls = make(NullSet)
+	var mask []string
+
+	for i, d := range s.dest {
+		if d == nil || s.paths[i] == "" || !isNull(d.(value.Value)) {
+			continue
+		}
+
+		if _, ok := s.nulls[s.paths[i]]; !ok {
+			s.nulls[s.paths[i]] = struct{}{}
+			mask = append(mask, s.paths[i])
+		}
+	}
+
+	if s.nullMask != nil && len(mask) > 0 {
+		msg.Set(s.nullMask, pr.ValueOfMessage((&fieldmaskpb.FieldMask{Paths: mask}).ProtoReflect()))
+	}
+}
+
+// ScanWithNulls is like Scan, and returns a NullSet for each message in result,
+// holding the fields of which the column was NULL.
+// This allows to distinguish NULL columns from zero values, for fields without presence.
+// See Scan for field name matching rules and options.
+func ScanWithNulls[M proto.Message](rows pgx.Rows, opts ...Option) (result []M, nulls []NullSet, err error) {
+	s, err := newScanner[M](rows, messageType[M](), opts, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.paths == nil {
+		if err = s.trackNulls(newOptions(opts)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for s.rows.Next() {
+		msg, err := s.scanRow()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result = append(result, msg)
+		nulls = append(nulls, s.nulls)
+	}
+
+	return result, nulls, nil
+}
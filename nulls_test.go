@@ -0,0 +1,114 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestNullSet(t *testing.T) {
+	s := NullSet{"title": {}, "child.name": {}}
+
+	if !s.Has("title") || s.Has("id") {
+		t.Errorf("NullSet.Has() = %v, %v, want true, false", s.Has("title"), s.Has("id"))
+	}
+
+	if got, want := s.Paths(), []string{"child.name", "title"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NullSet.Paths() = %v, want %v", got, want)
+	}
+}
+
+func TestScanWithNulls(t *testing.T) {
+	names := []string{"id", "title", "child.name", "foo"}
+	rows := [][]interface{}{
+		{int32(1), "", "bar", "x"},
+		{int32(2), nil, nil, nil},
+	}
+
+	got, nulls, err := ScanWithNulls[*support.Tracked](newTestRows(names, rows), WithIgnoreUnknown())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*support.Tracked{
+		{Id: 1, Child: &support.Child{Name: "bar"}},
+		{Id: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ScanWithNulls() =\n%v\nwant\n%v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("ScanWithNulls() =\n%v\nwant\n%v", got[i], want[i])
+		}
+	}
+
+	wantNulls := []NullSet{{}, {"title": {}, "child.name": {}}}
+	if !reflect.DeepEqual(nulls, wantNulls) {
+		t.Errorf("ScanWithNulls() nulls = %v, want %v", nulls, wantNulls)
+	}
+
+	if _, _, err = ScanWithNulls[*support.Tracked](newTestRows([]string{"foo"}, nil)); err == nil {
+		t.Error("ScanWithNulls: expected error, got nil")
+	}
+	if _, _, err = ScanWithNulls[*support.Tracked](newTestRows(names, rows[1:]), WithIgnoreUnknown(), WithNullMask("title")); err == nil {
+		t.Error("ScanWithNulls: expected error, got nil")
+	}
+	if _, _, err = ScanWithNulls[*support.Tracked](newTestRows(names, [][]interface{}{{1, 2}})); err == nil {
+		t.Error("ScanWithNulls: expected error, got nil")
+	}
+}
+
+func TestScan_nullMask(t *testing.T) {
+	names := []string{"title", "id", "child__id", "child__name"}
+	rows := [][]interface{}{
+		{nil, int32(1), nil, "bar"},
+		{"foo", int32(2), int32(3), "baz"},
+	}
+
+	got, err := Scan[*support.Tracked](newTestRows(names, rows), WithNullMask("nulls"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*support.Tracked{
+		{Id: 1, Child: &support.Child{Name: "bar"}, Nulls: &fieldmaskpb.FieldMask{Paths: []string{"title", "child.id"}}},
+		{Id: 2, Title: "foo", Child: &support.Child{Id: 3, Name: "baz"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Scan() =\n%v\nwant\n%v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("Scan() =\n%v\nwant\n%v", got[i], want[i])
+		}
+	}
+
+	for _, field := range []string{"foo", "title", "child"} {
+		if _, err = Scan[*support.Tracked](newTestRows(names, rows), WithNullMask(field)); err == nil {
+			t.Errorf("Scan() with null mask %s: expected error, got nil", field)
+		}
+	}
+}
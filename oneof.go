@@ -0,0 +1,49 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"github.com/muhlemmer/pbpgx/internal/value"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// oneofMemberValue sets a member of a oneof,
+// ensuring that at most one member is set from the scanned columns.
+type oneofMemberValue struct {
+	value.Value
+	fd        pr.FieldDescriptor
+	firstWins bool
+}
+
+func (v *oneofMemberValue) SetTo(msg pr.Message) error {
+	if isNull(v.Value) {
+		return nil
+	}
+
+	od := v.fd.ContainingOneof()
+	if set := msg.WhichOneof(od); set != nil && set.Number() != v.fd.Number() {
+		if v.firstWins {
+			return nil
+		}
+		return value.OneofConflict(od, set, v.fd)
+	}
+
+	return v.Value.SetTo(msg)
+}
@@ -0,0 +1,147 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"testing"
+
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestScan_oneof(t *testing.T) {
+	type args struct {
+		names []string
+		rows  [][]interface{}
+		opts  []Option
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantResults []*support.Supported
+		wantErr     bool
+	}{
+		{
+			"members",
+			args{
+				[]string{"ob", "oi"},
+				[][]interface{}{
+					{true, nil},
+					{nil, int32(0)},
+					{nil, nil},
+				},
+				nil,
+			},
+			[]*support.Supported{
+				{O: &support.Supported_Ob{Ob: true}},
+				{O: &support.Supported_Oi{Oi: 0}},
+				{},
+			},
+			false,
+		},
+		{
+			"conflict",
+			args{
+				[]string{"ob", "oi"},
+				[][]interface{}{
+					{true, int32(1)},
+				},
+				nil,
+			},
+			nil,
+			true,
+		},
+		{
+			"first wins",
+			args{
+				[]string{"oi", "ob"},
+				[][]interface{}{
+					{int32(1), true},
+				},
+				[]Option{WithOneofFirstWins()},
+			},
+			[]*support.Supported{
+				{O: &support.Supported_Oi{Oi: 1}},
+			},
+			false,
+		},
+		{
+			"discriminator",
+			args{
+				[]string{"o", "ob", "oi"},
+				[][]interface{}{
+					{"ob", nil, nil},
+					{"oi", nil, int32(2)},
+					{nil, nil, nil},
+				},
+				nil,
+			},
+			[]*support.Supported{
+				{O: &support.Supported_Ob{Ob: false}},
+				{O: &support.Supported_Oi{Oi: 2}},
+				{},
+			},
+			false,
+		},
+		{
+			"discriminator conflict",
+			args{
+				[]string{"o", "ob"},
+				[][]interface{}{
+					{"oi", true},
+				},
+				nil,
+			},
+			nil,
+			true,
+		},
+		{
+			"discriminator unknown member",
+			args{
+				[]string{"o"},
+				[][]interface{}{
+					{"foo"},
+				},
+				nil,
+			},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResults, err := Scan[*support.Supported](newTestRows(tt.args.names, tt.args.rows), tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if len(gotResults) != len(tt.wantResults) {
+				t.Errorf("Scan() =\n%s\nwant\n%s", gotResults, tt.wantResults)
+			}
+
+			for i, want := range tt.wantResults {
+				if !proto.Equal(gotResults[i], want) {
+					t.Errorf("Scan() =\n%s\nwant\n%s", gotResults[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"github.com/muhlemmer/pbpgx/internal/value"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Option modifies the mapping between message fields and columns,
+// during scanning and argument parsing.
+// Options are created by the With... functions of this package.
+type Option = value.Option
+
+func newOptions(opts []Option) *value.Options {
+	return value.NewOptions(opts)
+}
+
+// Encoding of a field in a database column,
+// for field types which can be stored in more than one column type.
+type Encoding = value.Encoding
+
+const (
+	// Auto selects the encoding based on the column's data type, when known.
+	// This is the case during scanning, where the data type is obtained from the result's field descriptions.
+	// Otherwise, for example when parsing arguments, the default encoding of the field type is used.
+	Auto Encoding = value.Auto
+
+	// EnumNumber encodes enum values by their number, in integer columns.
+	// This is the default for enum fields.
+	EnumNumber Encoding = value.EnumNumber
+
+	// EnumName encodes enum values by their name, in text or PostgreSQL ENUM columns.
+	EnumName Encoding = value.EnumName
+
+	// JSON encodes the field as JSON, in json or jsonb columns.
+	// Values are encoded following protojson rules.
+	// This is the default for map fields.
+	// Message fields and repeated message fields can be encoded as JSON as well.
+	// Auto selects JSON when scanning message fields of unregistered types from json or jsonb columns,
+	// such as json_agg(...) or jsonb_build_object(...). Null elements of a JSON array of messages,
+	// such as the result of json_agg(...) over an outer join, are handled like NULL array elements.
+	JSON Encoding = value.JSON
+
+	// Hstore encodes map<string, string> fields in hstore columns.
+	// Auto selects Hstore for such fields when scanning from columns
+	// which are not of a JSON or text type.
+	Hstore Encoding = value.Hstore
+
+	// Binary encodes message fields in the protocol buffers wire format,
+	// in bytea columns. Repeated message fields are encoded in bytea array columns.
+	// This is a compact alternative to JSON, for messages which do not need to be queried on.
+	Binary Encoding = value.Binary
+
+	// UnsignedCast encodes uint32 and fixed32 fields in integer columns
+	// and uint64 and fixed64 fields in bigint columns.
+	// Values beyond the maximum of the signed column type can't be written.
+	// This is the default for unsigned fields.
+	// Auto selects UnsignedCast when scanning from integer columns for 32 bit
+	// and bigint columns for 64 bit fields.
+	UnsignedCast Encoding = value.UnsignedCast
+
+	// UnsignedWide encodes uint32 and fixed32 fields in bigint columns
+	// and uint64 and fixed64 fields in numeric(20,0) columns, so that the full range can be stored.
+	// Scanned values are range checked.
+	// Auto selects UnsignedWide when scanning from other integer or numeric columns.
+	UnsignedWide Encoding = value.UnsignedWide
+
+	// Composite encodes message fields in composite type or record columns, such as row(...),
+	// and repeated message fields in their arrays, such as array_agg(row(...)).
+	// Attributes are matched to fields by name when the composite type is registered
+	// in the connection's pgtype.ConnInfo, otherwise by position in order of declaration.
+	// Auto selects Composite when scanning message fields of unregistered types
+	// from record or user defined composite columns.
+	// Values are written as composite literals in text format.
+	Composite Encoding = value.Composite
+
+	// Array encodes repeated message fields in multi-dimensional array columns, such as integer[][].
+	// Each message is a row of the first dimension and has a single repeated field,
+	// holding the scalar or enum elements of the row, or the rows of the next dimension.
+	// All rows in a dimension must be of the same length.
+	// Auto selects Array for repeated fields of such messages.
+	Array Encoding = value.Array
+
+	// UUID encodes string fields in uuid columns, in the canonical hyphenated form,
+	// and bytes fields as 16 byte UUIDs.
+	// Auto selects UUID when scanning from uuid columns.
+	UUID Encoding = value.UUID
+
+	// Inet encodes string fields in inet or cidr columns.
+	// Auto selects Inet when scanning from inet or cidr columns.
+	Inet Encoding = value.Inet
+
+	// Macaddr encodes string fields in macaddr columns.
+	// Auto selects Macaddr when scanning from macaddr columns.
+	Macaddr Encoding = value.Macaddr
+
+	// Numeric encodes string fields in numeric columns, without loss of precision.
+	// Strings are decimals without exponent, or NaN.
+	// Auto selects Numeric when scanning from numeric columns.
+	Numeric Encoding = value.Numeric
+)
+
+// WithEncoding sets the Encoding for the named column.
+func WithEncoding(column string, enc Encoding) Option {
+	return value.NewOption(func(o *value.Options) {
+		if o.Encodings == nil {
+			o.Encodings = make(map[string]Encoding)
+		}
+
+		o.Encodings[column] = enc
+	})
+}
+
+// WithMessageEncoding sets the Encoding for all message fields of types
+// without registered support, such as nested messages defined in your own proto files.
+// By default, such fields result in an error.
+// The Encoding set for a specific column with WithEncoding takes precedence.
+func WithMessageEncoding(enc Encoding) Option {
+	return value.NewOption(func(o *value.Options) {
+		o.MessageEncoding = enc
+	})
+}
+
+// WithProtoJSON sets the protojson options used for fields with the JSON Encoding.
+// For example, UseProtoNames can be set to write proto field names instead of JSON names,
+// and DiscardUnknown can be set to ignore unknown fields when reading.
+func WithProtoJSON(mo protojson.MarshalOptions, uo protojson.UnmarshalOptions) Option {
+	return value.NewOption(func(o *value.Options) {
+		o.JSONMarshal = mo
+		o.JSONUnmarshal = uo
+	})
+}
+
+// WithUnsignedEncoding sets the Encoding for all unsigned integer fields.
+// The Encoding set for a specific column with WithEncoding takes precedence.
+func WithUnsignedEncoding(enc Encoding) Option {
+	return value.NewOption(func(o *value.Options) {
+		o.UnsignedEncoding = enc
+	})
+}
+
+// WithIgnoreUnknown skips result columns which do not map to a field, during scanning.
+// By default, an error is returned for such columns.
+// This allows queries such as "SELECT *" to keep working when a column is added to the table,
+// before the message type is updated.
+func WithIgnoreUnknown() Option {
+	return value.NewOption(func(o *value.Options) {
+		o.Unknown = value.IgnoreUnknown
+	})
+}
+
+// WithCollectUnknown sets result columns which do not map to a field in the named field, during scanning.
+// The field must be of type map<string, string> or google.protobuf.Struct.
+// Values are set in their text representation, keyed by column name.
+// NULL values are omitted from a map and set as null in a Struct.
+func WithCollectUnknown(field string) Option {
+	return value.NewOption(func(o *value.Options) {
+		o.Unknown = value.CollectUnknown
+		o.UnknownField = field
+	})
+}
+
+// WithOneofFirstWins keeps the first member of a oneof in column order, during scanning,
+// when the columns of more than one member are not NULL.
+// By default, an error is returned in such case.
+func WithOneofFirstWins() Option {
+	return value.NewOption(func(o *value.Options) {
+		o.OneofFirstWins = true
+	})
+}
+
+// WithNullMask sets the paths of fields which are scanned from NULL columns in the named field,
+// which must be of type google.protobuf.FieldMask.
+// The field is left unset when no column is NULL.
+// This allows to distinguish NULL columns from zero values, for fields without presence.
+// The null mask is set by Scan, ScanOne, ScanStream and ScanGrouped, see also ScanWithNulls.
+func WithNullMask(field string) Option {
+	return value.NewOption(func(o *value.Options) {
+		o.NullMask = field
+	})
+}
+
+// WithSkipNullElements leaves NULL elements of arrays out of repeated fields, during scanning.
+// This includes record arrays, such as array_agg(row(...)),
+// and null elements of JSON arrays of messages, such as json_agg(...) over an outer join.
+// By default, an error is returned for arrays with NULL elements.
+func WithSkipNullElements() Option {
+	return value.NewOption(func(o *value.Options) {
+		o.NullElements = value.NullElementSkip
+	})
+}
+
+// WithZeroNullElements sets NULL elements of arrays as the zero value
+// of the element type in repeated fields, during scanning.
+// For repeated message fields this is an empty message.
+// By default, an error is returned for arrays with NULL elements.
+func WithZeroNullElements() Option {
+	return value.NewOption(func(o *value.Options) {
+		o.NullElements = value.NullElementZero
+	})
+}
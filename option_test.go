@@ -0,0 +1,57 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/muhlemmer/pbpgx/internal/value"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func Test_newOptions(t *testing.T) {
+	got := newOptions([]Option{
+		WithEncoding("foo", EnumName),
+		WithEncoding("bar", EnumNumber),
+		WithMessageEncoding(JSON),
+		Option{}, // Zero Option is a no-op.
+		WithUnsignedEncoding(UnsignedWide),
+		WithProtoJSON(
+			protojson.MarshalOptions{UseProtoNames: true},
+			protojson.UnmarshalOptions{DiscardUnknown: true},
+		),
+	})
+
+	want := &value.Options{
+		Encodings: map[string]Encoding{
+			"foo": EnumName,
+			"bar": EnumNumber,
+		},
+		MessageEncoding:  JSON,
+		UnsignedEncoding: UnsignedWide,
+		JSONMarshal:      protojson.MarshalOptions{UseProtoNames: true},
+		JSONUnmarshal:    protojson.UnmarshalOptions{DiscardUnknown: true},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newOptions() =\n%v\nwant\n%v", got, want)
+	}
+}
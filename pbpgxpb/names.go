@@ -0,0 +1,55 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// Package pbpgxpb defines protocol buffer options for pbpgx,
+// to map messages to table names and fields to column names.
+// See options.proto for usage.
+package pbpgxpb
+
+import (
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ColumnName returns the column name of a field,
+// as set by the (pbpgx.column) option, or the field name if not set.
+func ColumnName(fd pr.FieldDescriptor) string {
+	if name, _ := proto.GetExtension(fd.Options(), E_Column).(string); name != "" {
+		return name
+	}
+
+	return string(fd.Name())
+}
+
+// TableName returns the table name of a message,
+// as set by the (pbpgx.table) option, or an empty string if not set.
+func TableName(md pr.MessageDescriptor) string {
+	name, _ := proto.GetExtension(md.Options(), E_Table).(string)
+	return name
+}
+
+// EnumColumnName returns the column name of an enum value,
+// as set by the (pbpgx.enum_column) option, or the enum value name if not set.
+func EnumColumnName(ev pr.EnumValueDescriptor) string {
+	if name, _ := proto.GetExtension(ev.Options(), E_EnumColumn).(string); name != "" {
+		return name
+	}
+
+	return string(ev.Name())
+}
@@ -0,0 +1,76 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgxpb_test
+
+import (
+	"testing"
+
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"github.com/muhlemmer/pbpgx/pbpgxpb"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestColumnName(t *testing.T) {
+	fields := new(support.Legacy).ProtoReflect().Descriptor().Fields()
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"id", "legacy_id"},
+		{"title", "legacy_title"},
+		{"data", "data"},
+	}
+	for _, tt := range tests {
+		if got := pbpgxpb.ColumnName(fields.ByName(pr.Name(tt.field))); got != tt.want {
+			t.Errorf("ColumnName(%s) = %s, want %s", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestTableName(t *testing.T) {
+	tests := []struct {
+		md   pr.MessageDescriptor
+		want string
+	}{
+		{new(support.Legacy).ProtoReflect().Descriptor(), "legacy_rw"},
+		{new(support.Simple).ProtoReflect().Descriptor(), ""},
+	}
+	for _, tt := range tests {
+		if got := pbpgxpb.TableName(tt.md); got != tt.want {
+			t.Errorf("TableName(%s) = %s, want %s", tt.md.FullName(), got, tt.want)
+		}
+	}
+}
+
+func TestEnumColumnName(t *testing.T) {
+	tests := []struct {
+		ev   pr.EnumValueDescriptor
+		want string
+	}{
+		{support.LegacyColumns_legacy_data.Descriptor().Values().ByNumber(2), "data"},
+		{support.SimpleColumns_title.Descriptor().Values().ByNumber(1), "title"},
+	}
+	for _, tt := range tests {
+		if got := pbpgxpb.EnumColumnName(tt.ev); got != tt.want {
+			t.Errorf("EnumColumnName(%s) = %s, want %s", tt.ev.FullName(), got, tt.want)
+		}
+	}
+}
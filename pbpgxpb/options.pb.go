@@ -0,0 +1,185 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.1
+// source: pbpgxpb/options.proto
+
+//
+//SPDX-License-Identifier: AGPL-3.0-only
+//
+//Copyright (C) 2021, Tim Möhlmann
+//
+//This program is free software: you can redistribute it and/or modify
+//it under the terms of the GNU Affero General Public License as published by
+//the Free Software Foundation, either version 3 of the License, or
+//(at your option) any later version.
+//
+//This program is distributed in the hope that it will be useful,
+//but WITHOUT ANY WARRANTY; without even the implied warranty of
+//MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//GNU Affero General Public License for more details.
+//
+//You should have received a copy of the GNU Affero General Public License
+//along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package pbpgxpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var file_pbpgxpb_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51200,
+		Name:          "pbpgx.column",
+		Tag:           "bytes,51200,opt,name=column",
+		Filename:      "pbpgxpb/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51200,
+		Name:          "pbpgx.table",
+		Tag:           "bytes,51200,opt,name=table",
+		Filename:      "pbpgxpb/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51201,
+		Name:          "pbpgx.range",
+		Tag:           "varint,51201,opt,name=range",
+		Filename:      "pbpgxpb/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51200,
+		Name:          "pbpgx.enum_column",
+		Tag:           "bytes,51200,opt,name=enum_column",
+		Filename:      "pbpgxpb/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// Column name of the field.
+	// By default, the column name equals the field name.
+	//
+	//   int64 created_at = 1 [(pbpgx.column) = "created"];
+	//
+	// optional string column = 51200;
+	E_Column = &file_pbpgxpb_options_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// Table name of the message, used by crud.Table when no table name is passed.
+	//
+	//   option (pbpgx.table) = "products";
+	//
+	// optional string table = 51200;
+	E_Table = &file_pbpgxpb_options_proto_extTypes[1]
+	// Range marks a message as a PostgreSQL range type, such as tstzrange, daterange, int8range or numrange.
+	// The message has the fields lower and upper, which are unset for unbounded ranges.
+	// These must be messages, such as google.protobuf.Timestamp, or optional scalar fields.
+	// The optional fields lower_inclusive, upper_inclusive and empty are of type bool.
+	// Without inclusivity fields, the lower bound is inclusive and the upper bound exclusive.
+	//
+	//   option (pbpgx.range) = true;
+	//
+	// optional bool range = 51201;
+	E_Range = &file_pbpgxpb_options_proto_extTypes[2]
+)
+
+// Extension fields to descriptorpb.EnumValueOptions.
+var (
+	// Column name of an enum value, for enums used as column names in queries.
+	// By default, the column name equals the name of the enum value.
+	//
+	//   created_at = 1 [(pbpgx.enum_column) = "created"];
+	//
+	// optional string enum_column = 51200;
+	E_EnumColumn = &file_pbpgxpb_options_proto_extTypes[3]
+)
+
+var File_pbpgxpb_options_proto protoreflect.FileDescriptor
+
+var file_pbpgxpb_options_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x70, 0x62, 0x70, 0x67, 0x78, 0x70, 0x62, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x62, 0x70, 0x67, 0x78, 0x1a, 0x20,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x3a, 0x37, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x80, 0x90, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x3a, 0x37, 0x0a, 0x05, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x80, 0x90, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x3a, 0x37, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x81, 0x90, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x3a, 0x44, 0x0a, 0x0b, 0x65,
+	0x6e, 0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x21, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75,
+	0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x80, 0x90,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x75, 0x6d, 0x43, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6d, 0x75, 0x68, 0x6c, 0x65, 0x6d, 0x6d, 0x65, 0x72, 0x2f, 0x70, 0x62, 0x70, 0x67, 0x78, 0x2f,
+	0x70, 0x62, 0x70, 0x67, 0x78, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_pbpgxpb_options_proto_goTypes = []interface{}{
+	(*descriptorpb.FieldOptions)(nil),     // 0: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil),   // 1: google.protobuf.MessageOptions
+	(*descriptorpb.EnumValueOptions)(nil), // 2: google.protobuf.EnumValueOptions
+}
+var file_pbpgxpb_options_proto_depIdxs = []int32{
+	0, // 0: pbpgx.column:extendee -> google.protobuf.FieldOptions
+	1, // 1: pbpgx.table:extendee -> google.protobuf.MessageOptions
+	1, // 2: pbpgx.range:extendee -> google.protobuf.MessageOptions
+	2, // 3: pbpgx.enum_column:extendee -> google.protobuf.EnumValueOptions
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	0, // [0:4] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pbpgxpb_options_proto_init() }
+func file_pbpgxpb_options_proto_init() {
+	if File_pbpgxpb_options_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pbpgxpb_options_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 4,
+			NumServices:   0,
+		},
+		GoTypes:           file_pbpgxpb_options_proto_goTypes,
+		DependencyIndexes: file_pbpgxpb_options_proto_depIdxs,
+		ExtensionInfos:    file_pbpgxpb_options_proto_extTypes,
+	}.Build()
+	File_pbpgxpb_options_proto = out.File
+	file_pbpgxpb_options_proto_rawDesc = nil
+	file_pbpgxpb_options_proto_goTypes = nil
+	file_pbpgxpb_options_proto_depIdxs = nil
+}
@@ -23,7 +23,9 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/muhlemmer/pbpgx/pbpgxpb"
 	"github.com/muhlemmer/stringx"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
 )
 
 const (
@@ -39,6 +41,19 @@ type ColName interface {
 	fmt.Stringer
 }
 
+// columnName returns the column name of col.
+// For protobuf enums this is the (pbpgx.enum_column) option of the enum value, if set.
+// Otherwise the result of the String method is returned.
+func columnName[Col ColName](col Col) string {
+	if e, ok := any(col).(pr.Enum); ok {
+		if ev := e.Descriptor().Values().ByNumber(e.Number()); ev != nil {
+			return pbpgxpb.EnumColumnName(ev)
+		}
+	}
+
+	return col.String()
+}
+
 // Builder for queries
 type Builder[Col ColName] struct {
 	stringx.Builder
@@ -88,7 +103,7 @@ func (b *Builder[Col]) WriteColumnSpec(columns []Col) {
 		if i != 0 {
 			b.WriteString(", ")
 		}
-		b.WriteEnclosedString(col.String(), stringx.DoubleQuotes)
+		b.WriteEnclosedString(columnName(col), stringx.DoubleQuotes)
 	}
 }
 
@@ -138,6 +138,15 @@ func TestBuilder_WriteReturnClause(t *testing.T) {
 			},
 			` RETURNING "id", "title", "data"`,
 		},
+		{
+			"enum column option",
+			[]ColName{
+				support.LegacyColumns_legacy_id,
+				support.LegacyColumns_legacy_title,
+				support.LegacyColumns_legacy_data,
+			},
+			` RETURNING "legacy_id", "legacy_title", "data"`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -19,6 +19,8 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
 package query
 
+import "github.com/muhlemmer/stringx"
+
 // WhereFunc are callback functions that writes
 // the "WHERE" clause to a query.
 type WhereFunc[Col ColName] func(b *Builder[Col])
@@ -26,16 +28,33 @@ type WhereFunc[Col ColName] func(b *Builder[Col])
 // Where ID writes a where clause in the form:
 //   WHERE "id" = $1
 func WhereID[Col ColName](b *Builder[Col]) {
-	b.WriteString(" WHERE \"id\" = ")
-	b.WritePosArgs(1)
+	WhereColumnFunc[Col]("id")(b)
 }
 
 // WhereIDInFunc returns a function which writes a where clause in the form:
 //   WHERE "id" IN $1, $2, $N...
 func WhereIDInFunc[Col ColName](n int) WhereFunc[Col] {
+	return WhereColumnInFunc[Col]("id", n)
+}
+
+// WhereColumnFunc returns a function which writes a where clause for the named column in the form:
+//   WHERE "column" = $1
+func WhereColumnFunc[Col ColName](column string) WhereFunc[Col] {
+	return func(b *Builder[Col]) {
+		b.WriteString(" WHERE ")
+		b.WriteEnclosedString(column, stringx.DoubleQuotes)
+		b.WriteString(" = ")
+		b.WritePosArgs(1)
+	}
+}
+
+// WhereColumnInFunc returns a function which writes a where clause for the named column in the form:
+//   WHERE "column" IN $1, $2, $N...
+func WhereColumnInFunc[Col ColName](column string, n int) WhereFunc[Col] {
 	return func(b *Builder[Col]) {
-		b.WriteString(" WHERE \"id\" IN ")
-		b.WriteByte('(')
+		b.WriteString(" WHERE ")
+		b.WriteEnclosedString(column, stringx.DoubleQuotes)
+		b.WriteString(" IN (")
 		b.WritePosArgs(n)
 		b.WriteByte(')')
 	}
@@ -48,3 +48,31 @@ func Test_whereIDInFunc(t *testing.T) {
 		t.Errorf("whereID = %s, want %s", got, want)
 	}
 }
+
+func Test_whereColumnFunc(t *testing.T) {
+	b := &Builder[ColName]{
+		argPos: 2,
+	}
+
+	WhereColumnFunc[ColName]("legacy_id")(b)
+
+	const want = " WHERE \"legacy_id\" = $3"
+
+	if got := b.String(); got != want {
+		t.Errorf("WhereColumnFunc = %s, want %s", got, want)
+	}
+}
+
+func Test_whereColumnInFunc(t *testing.T) {
+	b := &Builder[ColName]{
+		argPos: 2,
+	}
+
+	WhereColumnInFunc[ColName]("legacy_id", 3)(b)
+
+	const want = " WHERE \"legacy_id\" IN ($3, $4, $5)"
+
+	if got := b.String(); got != want {
+		t.Errorf("WhereColumnInFunc = %s, want %s", got, want)
+	}
+}
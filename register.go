@@ -0,0 +1,212 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/value"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Value converts between a message field and a column.
+// A Value is passed to pgx as scan destination and as query argument.
+// Embedding a pgtype type, such as pgtype.Text, provides the transcoder methods.
+type Value interface {
+	pgtype.ValueTranscoder
+
+	// SetTo sets the field in msg to the scanned value.
+	// It is called for each scanned row, including rows where the column is NULL.
+	SetTo(msg pr.Message) error
+
+	// SetFrom sets the value from the field in msg, for use as query argument.
+	// It is only called when the field is set in msg.
+	SetFrom(msg pr.Message) error
+}
+
+// ValueConstructor returns a new Value for the field,
+// with its initial status set to status.
+// The field may be singular or repeated, as reported by fd.IsList().
+type ValueConstructor func(fd pr.FieldDescriptor, status pgtype.Status) (Value, error)
+
+// registeredValue adapts a Value to the internal value.Value interface.
+type registeredValue struct {
+	Value
+}
+
+func (v registeredValue) PGValue() pgtype.Value { return v.Value }
+
+func (v registeredValue) SetTo(msg pr.Message) error {
+	return v.Value.SetTo(concreteFields(msg))
+}
+
+func (v registeredValue) SetFrom(msg pr.Message) error {
+	return v.Value.SetFrom(concreteFields(msg))
+}
+
+// concreteFields returns msg, with message fields presented as their generated Go type
+// when msg is a dynamic message, so that registered Values can rely on type assertions.
+// Fields of types which are not registered in protoregistry.GlobalTypes are left unchanged.
+func concreteFields(msg pr.Message) pr.Message {
+	if _, ok := msg.Interface().(*dynamicpb.Message); ok {
+		return dynamicFields{msg}
+	}
+
+	return msg
+}
+
+// fieldType returns the generated message type of fd, or nil.
+func fieldType(fd pr.FieldDescriptor) pr.MessageType {
+	if fd.Message() == nil || fd.IsMap() {
+		return nil
+	}
+
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(fd.Message().FullName())
+	if err != nil {
+		return nil
+	}
+
+	return mt
+}
+
+// convertMessage returns src as a message of type dst, by merging it into dst.
+func convertMessage(dst, src pr.Message) pr.Message {
+	proto.Merge(dst.Interface(), src.Interface())
+	return dst
+}
+
+// dynamicFields presents the message fields of a dynamic message as their generated Go type.
+// Scalar fields and fields of types which are not generated are passed through.
+type dynamicFields struct {
+	pr.Message
+}
+
+func (m dynamicFields) Get(fd pr.FieldDescriptor) pr.Value {
+	mt := fieldType(fd)
+	switch {
+	case mt == nil || fd.IsList() && !m.Message.Has(fd):
+		return m.Message.Get(fd)
+	case !m.Message.Has(fd):
+		return pr.ValueOfMessage(mt.Zero())
+	case fd.IsList():
+		return pr.ValueOfList(generatedList{m.Message.Get(fd).List(), mt})
+	default:
+		return pr.ValueOfMessage(convertMessage(mt.New(), m.Message.Get(fd).Message()))
+	}
+}
+
+func (m dynamicFields) NewField(fd pr.FieldDescriptor) pr.Value {
+	mt := fieldType(fd)
+	switch {
+	case mt == nil:
+		return m.Message.NewField(fd)
+	case fd.IsList():
+		return pr.ValueOfList(generatedList{m.Message.NewField(fd).List(), mt})
+	default:
+		return pr.ValueOfMessage(mt.New())
+	}
+}
+
+func (m dynamicFields) Set(fd pr.FieldDescriptor, v pr.Value) {
+	if fieldType(fd) != nil {
+		switch x := v.Interface().(type) {
+		case generatedList:
+			v = pr.ValueOfList(x.List)
+		case pr.Message:
+			v = pr.ValueOfMessage(convertMessage(m.Message.NewField(fd).Message(), x))
+		}
+	}
+
+	m.Message.Set(fd, v)
+}
+
+// generatedList presents the elements of a list of dynamic messages as their generated Go type.
+// Elements are copied, so that modifications must be written back with Set.
+type generatedList struct {
+	pr.List
+	mt pr.MessageType
+}
+
+func (l generatedList) Get(i int) pr.Value {
+	return pr.ValueOfMessage(convertMessage(l.mt.New(), l.List.Get(i).Message()))
+}
+
+func (l generatedList) Set(i int, v pr.Value) {
+	l.List.Set(i, l.dynamic(v))
+}
+
+func (l generatedList) Append(v pr.Value) {
+	l.List.Append(l.dynamic(v))
+}
+
+func (l generatedList) NewElement() pr.Value {
+	return pr.ValueOfMessage(l.mt.New())
+}
+
+// dynamic returns the element v as a message of the list's implementation.
+func (l generatedList) dynamic(v pr.Value) pr.Value {
+	return pr.ValueOfMessage(convertMessage(l.List.NewElement().Message(), v.Message()))
+}
+
+// PreferredParamFormat returns the parameter format preferred by the wrapped Value,
+// or the binary format code if it has no preference.
+func (v registeredValue) PreferredParamFormat() int16 {
+	if p, ok := v.Value.(pgtype.ParamFormatPreferrer); ok {
+		return p.PreferredParamFormat()
+	}
+	return pgtype.BinaryFormatCode
+}
+
+// RegisterMessage registers c as constructor of Values for fields
+// of the message type with the full name,
+// for use by Scan, ScanOne, ScanStream, the Query functions and crud.Columns.ParseArgs.
+// Registered types take precedence over the built-in support for a message type,
+// unless an Encoding is set for a column with WithEncoding.
+//
+// Values are also used for dynamic messages, with ScanDynamic, QueryDynamic and crud.NewDynamicTable.
+// The message passed to SetTo and SetFrom then presents message fields as their generated Go type,
+// as registered in protoregistry.GlobalTypes, so that Values can use type assertions in both cases.
+// Fields must be written with Set, as changes through Mutable are not converted.
+//
+// RegisterMessage is not safe for concurrent use with itself or with scanning.
+// It is meant to be called during program initialization, for example from an init function.
+func RegisterMessage(name pr.FullName, c ValueConstructor) {
+	value.RegisterMessage(name, func(fd pr.FieldDescriptor, status pgtype.Status, _ uint32) (value.Value, error) {
+		v, err := c(fd, status)
+		if err != nil {
+			return nil, err
+		}
+
+		return registeredValue{v}, nil
+	})
+}
+
+// RegisterRange registers the message type with the full name as a PostgreSQL range type,
+// as an alternative to the (pbpgx.range) message option,
+// for message types which can't be changed.
+// See the option in pbpgxpb/options.proto for the fields a range message must have.
+//
+// RegisterRange is not safe for concurrent use with itself or with scanning.
+// It is meant to be called during program initialization, for example from an init function.
+func RegisterRange(name pr.FullName) {
+	value.RegisterRange(name)
+}
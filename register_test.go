@@ -0,0 +1,224 @@
+/*
+SPDX-License-Identifier: AGPL-3.0-only
+
+Copyright (C) 2021, Tim Möhlmann
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package pbpgx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/pbpgx/internal/support"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// customValue converts support.Custom messages from and to text columns.
+type customValue struct {
+	pgtype.Text
+	fd pr.FieldDescriptor
+}
+
+func (v *customValue) SetTo(msg pr.Message) error {
+	if v.Status != pgtype.Present {
+		return nil
+	}
+
+	msg.Set(v.fd, pr.ValueOfMessage((&support.Custom{Value: v.String}).ProtoReflect()))
+	return nil
+}
+
+func (v *customValue) SetFrom(msg pr.Message) error {
+	cst := msg.Get(v.fd).Message().Interface().(*support.Custom)
+	return v.Set(cst.GetValue())
+}
+
+var errCustomList = errors.New("repeated Custom not supported")
+
+func newCustomValue(fd pr.FieldDescriptor, status pgtype.Status) (Value, error) {
+	if fd.IsList() {
+		return nil, errCustomList
+	}
+
+	return &customValue{pgtype.Text{Status: status}, fd}, nil
+}
+
+func TestRegisterMessage(t *testing.T) {
+	RegisterMessage((*support.Custom)(nil).ProtoReflect().Descriptor().FullName(), newCustomValue)
+
+	t.Run("Scan", func(t *testing.T) {
+		gotResults, err := Scan[*support.Registered](newTestRows(
+			[]string{"cst"},
+			[][]interface{}{
+				{"foo"},
+				{nil},
+			},
+		))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantResults := []*support.Registered{
+			{Cst: &support.Custom{Value: "foo"}},
+			{},
+		}
+
+		if len(gotResults) != len(wantResults) {
+			t.Fatalf("Scan() =\n%s\nwant\n%s", gotResults, wantResults)
+		}
+
+		for i, want := range wantResults {
+			if !proto.Equal(gotResults[i], want) {
+				t.Errorf("Scan() =\n%s\nwant\n%s", gotResults[i], want)
+			}
+		}
+	})
+
+	t.Run("Constructor error", func(t *testing.T) {
+		_, err := Scan[*support.Registered](newTestRows(
+			[]string{"r_cst"},
+			[][]interface{}{
+				{[]string{"foo"}},
+			},
+		))
+		if !errors.Is(err, errCustomList) {
+			t.Errorf("Scan() error = %v, want %v", err, errCustomList)
+		}
+	})
+
+	t.Run("SetFrom", func(t *testing.T) {
+		msg := (&support.Registered{Cst: &support.Custom{Value: "foo"}}).ProtoReflect()
+		fd := msg.Descriptor().Fields().ByName("cst")
+
+		v, err := newOptions(nil).New(fd, pgtype.Null, "cst", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = v.SetFrom(msg); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := v.Get(); got != "foo" {
+			t.Errorf("SetFrom() Get = %v, want %v", got, "foo")
+		}
+
+		// pgtype.Text prefers the text format, which must not be hidden by the wrapper.
+		if got := v.(registeredValue).PreferredParamFormat(); got != pgtype.TextFormatCode {
+			t.Errorf("PreferredParamFormat() = %d, want %d", got, pgtype.TextFormatCode)
+		}
+	})
+
+	t.Run("Dynamic", func(t *testing.T) {
+		md := (*support.Registered)(nil).ProtoReflect().Descriptor()
+
+		got, err := ScanDynamic(newTestRows([]string{"cst"}, [][]interface{}{{"foo"}, {nil}}), md)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []*support.Registered{{Cst: &support.Custom{Value: "foo"}}, {}}
+		if len(got) != len(want) {
+			t.Fatalf("ScanDynamic() =\n%s\nwant\n%s", got, want)
+		}
+		for i := range want {
+			if !proto.Equal(got[i], want[i]) {
+				t.Errorf("ScanDynamic() =\n%s\nwant\n%s", got[i], want[i])
+			}
+		}
+
+		v, err := newOptions(nil).New(md.Fields().ByName("cst"), pgtype.Null, "cst", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = v.SetFrom(got[0].ProtoReflect()); err != nil {
+			t.Fatal(err)
+		}
+		if got := v.Get(); got != "foo" {
+			t.Errorf("SetFrom() Get = %v, want %v", got, "foo")
+		}
+	})
+}
+
+func Test_dynamicFields(t *testing.T) {
+	md := (*support.Registered)(nil).ProtoReflect().Descriptor()
+	cst, rcst := md.Fields().ByName("cst"), md.Fields().ByName("r_cst")
+
+	dyn := dynamicpb.NewMessage(md)
+	m := concreteFields(dyn)
+
+	if _, ok := m.Get(cst).Message().Interface().(*support.Custom); !ok {
+		t.Errorf("Get() of unset field = %T, want %T", m.Get(cst).Message().Interface(), &support.Custom{})
+	}
+
+	m.Set(cst, pr.ValueOfMessage((&support.Custom{Value: "foo"}).ProtoReflect()))
+
+	list := m.NewField(rcst).List()
+	elem := list.NewElement()
+	elem.Message().Interface().(*support.Custom).Value = "bar"
+	list.Append(elem)
+	m.Set(rcst, pr.ValueOfList(list))
+
+	if got := m.Get(cst).Message().Interface().(*support.Custom).GetValue(); got != "foo" {
+		t.Errorf("Get() = %q, want %q", got, "foo")
+	}
+	if got := m.Get(rcst).List().Get(0).Message().Interface().(*support.Custom).GetValue(); got != "bar" {
+		t.Errorf("Get() list element = %q, want %q", got, "bar")
+	}
+
+	want := &support.Registered{Cst: &support.Custom{Value: "foo"}, RCst: []*support.Custom{{Value: "bar"}}}
+	if !proto.Equal(dyn, want) {
+		t.Errorf("dynamic message =\n%v\nwant\n%v", dyn, want)
+	}
+
+	if gen := (&support.Registered{}).ProtoReflect(); concreteFields(gen) != gen {
+		t.Error("concreteFields() of generated message: want unchanged")
+	}
+}
+
+func TestRegisterRange(t *testing.T) {
+	RegisterRange((*support.Span)(nil).ProtoReflect().Descriptor().FullName())
+
+	gotResults, err := Scan[*support.Booking](newTestRows(
+		[]string{"span", "seats"},
+		[][]interface{}{
+			{"[1,5)", "(,10)"},
+			{nil, "empty"},
+		},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantResults := []*support.Booking{
+		{
+			Span:  &support.Span{Lower: proto.Int32(1), Upper: proto.Int32(5)},
+			Seats: &support.IntRange{Upper: proto.Int64(10)},
+		},
+		{
+			Seats: &support.IntRange{Empty: true},
+		},
+	}
+	for i, want := range wantResults {
+		if !proto.Equal(gotResults[i], want) {
+			t.Errorf("Scan() =\n%v\nwant\n%v", gotResults[i], want)
+		}
+	}
+}
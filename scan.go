@@ -29,22 +29,52 @@ import (
 	"github.com/muhlemmer/pbpgx/internal/value"
 	"google.golang.org/protobuf/proto"
 	pr "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
-func destinations(pfds pr.FieldDescriptors, pgfs []pgproto3.FieldDescription) ([]interface{}, error) {
+// destinations returns a scan destination for each field description.
+// Lists allows prefixed columns to map into repeated message fields, see nestedField.
+// Columns named after a oneof of md are scanned as its discriminator.
+func destinations(md pr.MessageDescriptor, pgfs []pgproto3.FieldDescription, opts *value.Options, lists bool) ([]interface{}, error) {
 	fields := make([]interface{}, len(pgfs))
 
 	for i, f := range pgfs {
-		pfd := pfds.ByName(pr.Name(string(f.Name)))
+		path, pfd := nestedField(md.Fields(), string(f.Name), opts, lists)
 		if pfd == nil {
-			return nil, fmt.Errorf("unknown field %s", f.Name)
+			if od := opts.Oneof(md.Oneofs(), string(f.Name)); od != nil {
+				fields[i] = opts.NewOneof(od, pgtype.Undefined)
+				continue
+			}
+
+			switch opts.Unknown {
+			case value.IgnoreUnknown:
+				// nil destinations are skipped by pgx.
+			case value.CollectUnknown:
+				v, err := opts.NewUnknown(md.Fields(), string(f.Name), f.DataTypeOID)
+				if err != nil {
+					return nil, err
+				}
+				fields[i] = v
+			default:
+				return nil, fmt.Errorf("unknown field %s", f.Name)
+			}
+
+			continue
 		}
 
-		v, err := value.New(pfd, pgtype.Undefined)
+		v, err := opts.New(pfd, pgtype.Undefined, string(f.Name), f.DataTypeOID)
 		if err != nil {
 			return nil, err
 		}
 
+		if od := pfd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			v = &oneofMemberValue{v, pfd, opts.OneofFirstWins}
+		}
+
+		if len(path) > 0 {
+			v = &nestedValue{v, path}
+		}
+
 		fields[i] = v
 	}
 
@@ -55,22 +85,59 @@ type scanner[M proto.Message] struct {
 	rows pgx.Rows
 	msg  pr.Message
 	dest []interface{}
+
+	// NULL tracking, see trackNulls.
+	paths    []string           // Field path of each column, nil when not tracking.
+	nullMask pr.FieldDescriptor // Set with WithNullMask.
+	nulls    NullSet            // NULL fields of the last scanned row.
 }
 
-func newScanner[M proto.Message](rows pgx.Rows) (*scanner[M], error) {
+// messageType returns the message type of M.
+func messageType[M proto.Message]() pr.MessageType {
 	var m M
+	return m.ProtoReflect().Type()
+}
+
+// newScanner returns a scanner for messages of type mt.
+// M must be the Go type of the messages created by mt, or proto.Message.
+// NULL columns are tracked when the WithNullMask option is passed.
+func newScanner[M proto.Message](rows pgx.Rows, mt pr.MessageType, opts []Option, lists bool) (*scanner[M], error) {
+	o := newOptions(opts)
+	msg := mt.Zero()
 
-	msg := m.ProtoReflect()
-	dest, err := destinations(msg.Descriptor().Fields(), rows.FieldDescriptions())
+	dest, err := destinations(msg.Descriptor(), rows.FieldDescriptions(), o, lists)
 	if err != nil {
 		return nil, err
 	}
 
-	return &scanner[M]{
+	s := &scanner[M]{
 		rows: rows,
 		msg:  msg,
 		dest: dest,
-	}, nil
+	}
+
+	if o.NullMask != "" {
+		if err = s.trackNulls(o); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// typeName returns the name of the scanned message type, for use in errors.
+// This is the Go type, or the full name of the message for dynamic messages.
+func (s *scanner[M]) typeName() string {
+	if _, ok := s.msg.Interface().(*dynamicpb.Message); ok {
+		return string(s.msg.Descriptor().FullName())
+	}
+
+	return fmt.Sprintf("%T", s.msg.Interface())
+}
+
+// columnError wraps an error for the column at index i.
+func (s *scanner[M]) columnError(i int, err error) error {
+	return fmt.Errorf("pbpgx.Scan into proto.Message %s: column %s: %w", s.typeName(), s.rows.FieldDescriptions()[i].Name, err)
 }
 
 func (s *scanner[M]) scanRow() (M, error) {
@@ -78,26 +145,28 @@ func (s *scanner[M]) scanRow() (M, error) {
 
 	if err := s.rows.Scan(s.dest...); err != nil {
 		var m M
-		return m, fmt.Errorf("pbpgx.Scan into proto.Message %T: %w", m, err)
+		return m, fmt.Errorf("pbpgx.Scan into proto.Message %s: %w", s.typeName(), err)
+	}
+
+	for i, d := range s.dest {
+		if d == nil {
+			continue
+		}
+		if err := d.(value.Value).SetTo(msg); err != nil {
+			var m M
+			return m, s.columnError(i, err)
+		}
 	}
 
-	for _, d := range s.dest {
-		d.(value.Value).SetTo(msg)
+	if s.paths != nil {
+		s.setNulls(msg)
 	}
 
 	return msg.Interface().(M), nil
 }
 
-// Scan returns a slice of proto messages of type M, filled with data from rows.
-// It matches field names from rows to field names of the proto message type M.
-// An error is returned if a column name in rows is not found in te message type's field names,
-// if a matched message field is of an unsupported type or any scan error reported by the pgx driver.
-func Scan[M proto.Message](rows pgx.Rows) (result []M, err error) {
-	s, err := newScanner[M](rows)
-	if err != nil {
-		return nil, err
-	}
-
+// scanAll scans all remaining rows.
+func (s *scanner[M]) scanAll() (result []M, err error) {
 	for s.rows.Next() {
 		msg, err := s.scanRow()
 		if err != nil {
@@ -107,25 +176,51 @@ func Scan[M proto.Message](rows pgx.Rows) (result []M, err error) {
 		result = append(result, msg)
 	}
 
-	return result, err
+	return result, nil
 }
 
-// ScanOne returns a single instance of proto Message with type M, filled with data from rows.
-// pgx.ErrNoRows is returned when there are rows to scan.
-// See Scan for field name matching rules.
-func ScanOne[M proto.Message](rows pgx.Rows) (M, error) {
-	var m M
+// scanOne scans the next row, or returns pgx.ErrNoRows.
+func (s *scanner[M]) scanOne() (M, error) {
+	if !s.rows.Next() {
+		var m M
+		return m, pgx.ErrNoRows
+	}
 
-	s, err := newScanner[M](rows)
+	return s.scanRow()
+}
+
+// Scan returns a slice of proto messages of type M, filled with data from rows.
+// It matches field names from rows to field names of the proto message type M,
+// or to the column names set with the (pbpgx.column) field option, see package pbpgxpb.
+// The WithNaming and WithCaseInsensitive options change how field names are matched.
+// Columns named with a prefix, such as "author.name" or "author__name", populate fields of nested messages.
+// Nested messages are left unset when all their columns are NULL.
+// At most one column of the members of a oneof may be non-NULL, unless the WithOneofFirstWins option is passed.
+// A column named after a oneof is a discriminator, holding the field name of the set member.
+// An error is returned if a column name in rows is not found in te message type's field names,
+// unless the WithIgnoreUnknown or WithCollectUnknown option is passed,
+// if a matched message field is of an unsupported type or any scan error reported by the pgx driver.
+// Options may be passed to modify the mapping of fields to columns.
+func Scan[M proto.Message](rows pgx.Rows, opts ...Option) (result []M, err error) {
+	s, err := newScanner[M](rows, messageType[M](), opts, false)
 	if err != nil {
-		return m, err
+		return nil, err
 	}
 
-	if !s.rows.Next() {
-		return m, pgx.ErrNoRows
+	return s.scanAll()
+}
+
+// ScanOne returns a single instance of proto Message with type M, filled with data from rows.
+// pgx.ErrNoRows is returned when there are rows to scan.
+// See Scan for field name matching rules and options.
+func ScanOne[M proto.Message](rows pgx.Rows, opts ...Option) (M, error) {
+	s, err := newScanner[M](rows, messageType[M](), opts, false)
+	if err != nil {
+		var m M
+		return m, err
 	}
 
-	return s.scanRow()
+	return s.scanOne()
 }
 
 type ServerStream[M proto.Message] interface {
@@ -137,9 +232,9 @@ type ServerStream[M proto.Message] interface {
 // ScanStream returns a nil error when rows is exhausted or an error when one is encountered,
 // durng scanning or sending.
 // Messages may already have been send when returning an error.
-// See Scan for field name matching rules.
-func ScanStream[M proto.Message](rows pgx.Rows, stream ServerStream[M]) error {
-	s, err := newScanner[M](rows)
+// See Scan for field name matching rules and options.
+func ScanStream[M proto.Message](rows pgx.Rows, stream ServerStream[M], opts ...Option) error {
+	s, err := newScanner[M](rows, messageType[M](), opts, false)
 	if err != nil {
 		return err
 	}
@@ -31,8 +31,14 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/muhlemmer/pbpgx/internal/support"
 	"github.com/muhlemmer/pbpgx/internal/value"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/decimal"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 type testRows struct {
@@ -57,7 +63,6 @@ func newTestRows(colNames []string, rows [][]interface{}) pgx.Rows {
 func (r *testRows) Close()                        { r.closed = true }
 func (r *testRows) Err() error                    { return r.err }
 func (r *testRows) CommandTag() pgconn.CommandTag { return nil } // no-op
-func (r *testRows) RawValues() [][]byte           { return nil } // no-op
 
 func (r *testRows) FieldDescriptions() []pgproto3.FieldDescription {
 	fds := make([]pgproto3.FieldDescription, len(r.names))
@@ -69,6 +74,20 @@ func (r *testRows) FieldDescriptions() []pgproto3.FieldDescription {
 	return fds
 }
 
+// RawValues returns the values of the current row, formatted with fmt.Sprint.
+// Nil values are returned as nil.
+func (r *testRows) RawValues() [][]byte {
+	raw := make([][]byte, len(r.rows[r.pos]))
+
+	for i, v := range r.rows[r.pos] {
+		if v != nil {
+			raw[i] = []byte(fmt.Sprint(v))
+		}
+	}
+
+	return raw
+}
+
 func (r *testRows) Next() bool {
 	r.pos++
 
@@ -156,18 +175,34 @@ func TestScan(t *testing.T) {
 				[]string{
 					"bl", "i32", "i64", "f", "d", "s", "bt", "u32", "u64", "ts",
 					"r_bl", "r_i32", "r_i64", "r_f", "r_d", "r_s",
-					"r_bt", "r_u32", "r_u64", "r_ts",
+					"r_bt", "r_u32", "r_u64", "r_ts", "en", "r_en",
+					"mp", "ts_mp", "w_i64", "w_s", "du", "r_du", "st", "dt", "dec",
+					"o_i32", "o_s", "o_ts",
 				},
 				[][]interface{}{
 					{
 						true, int32(1), int64(2), float32(1.1), float64(2.2), "Hello World!", []byte("Foo bar"), uint32(32), uint64(64), time.Unix(12, 34),
 						[]bool{true, false}, []int32{1, -1}, []int64{2, -2}, []float32{1.1, -1.1}, []float64{2.2, -2.2}, []string{"Hello", "World!"},
 						[][]byte{[]byte("foo"), []byte("bar")}, []uint32{32, 30}, []uint64{64, 60}, []time.Time{time.Unix(12, 34), time.Unix(34, 12)},
+						int32(1), []int32{2, 3},
+						[]byte(`{"foo": 1}`), []byte(`{"1": "1970-01-01T00:00:12Z"}`),
+						int64(0), "Hello",
+						90 * time.Second, []time.Duration{time.Second, -time.Millisecond},
+						[]byte(`{"foo": "bar"}`),
+						time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC), "12.50",
+						int32(0), "", time.Unix(1, 0),
 					},
 					{
 						false, int32(3), int64(4), float32(3.1), float64(4.2), "Bye World!", []byte{}, uint32(23), uint64(46), time.Unix(56, 78),
 						[]bool{false, true}, []int32{-1, 1}, []int64{-2, 2}, []float32{-1.1, 1.1}, []float64{-2.2, 2.2}, []string{"Bye", "World!"},
 						[][]byte{[]byte("bar"), []byte("foo")}, []uint32{30, 32}, []uint64{60, 64}, []time.Time{time.Unix(56, 78), time.Unix(78, 56)},
+						int32(0), []int32{},
+						nil, nil,
+						nil, nil,
+						nil, nil,
+						nil,
+						nil, nil,
+						nil, nil, nil,
 					},
 				},
 			},
@@ -205,6 +240,24 @@ func TestScan(t *testing.T) {
 							Nanos:   12,
 						},
 					},
+					En:  support.SimpleColumns_title,
+					REn: []support.SimpleColumns{support.SimpleColumns_data, support.SimpleColumns_created},
+					Mp:  map[string]int32{"foo": 1},
+					TsMp: map[int32]*timestamppb.Timestamp{
+						1: {Seconds: 12},
+					},
+					WI64: wrapperspb.Int64(0),
+					WS:   wrapperspb.String("Hello"),
+					Du:   durationpb.New(90 * time.Second),
+					RDu:  []*durationpb.Duration{durationpb.New(time.Second), durationpb.New(-time.Millisecond)},
+					St: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"foo": structpb.NewStringValue("bar"),
+					}},
+					Dt:   &date.Date{Year: 2022, Month: 2, Day: 28},
+					Dec:  &decimal.Decimal{Value: "12.50"},
+					OI32: proto.Int32(0),
+					OS:   proto.String(""),
+					OTs:  &timestamppb.Timestamp{Seconds: 1},
 				},
 				{
 					Bl:  false,
@@ -321,6 +374,181 @@ func TestScan_unsuported(t *testing.T) {
 	}
 }
 
+func TestScan_columnOption(t *testing.T) {
+	rows := newTestRows(
+		[]string{"legacy_id", "legacy_title", "data"},
+		[][]interface{}{
+			{int32(1), "foo", "bar"},
+		},
+	)
+
+	got, err := ScanOne[*support.Legacy](rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &support.Legacy{Id: 1, Title: "foo", Data: "bar"}
+	if !proto.Equal(got, want) {
+		t.Errorf("ScanOne() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Field names of fields with a column option do not match.
+	if _, err = ScanOne[*support.Legacy](newTestRows([]string{"title"}, [][]interface{}{{"foo"}})); err == nil {
+		t.Error("ScanOne: expected error, got nil")
+	}
+}
+
+func TestScan_unknown(t *testing.T) {
+	names := []string{"i32", "added", "also_added"}
+	rows := [][]interface{}{
+		{int32(1), "foo", nil},
+	}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    *support.Supported
+		wantErr bool
+	}{
+		{"error", nil, nil, true},
+		{"ignore", []Option{WithIgnoreUnknown()}, &support.Supported{I32: 1}, false},
+		{
+			"collect map",
+			[]Option{WithCollectUnknown("s_mp")},
+			&support.Supported{I32: 1, SMp: map[string]string{"added": "foo"}},
+			false,
+		},
+		{
+			"collect struct",
+			[]Option{WithCollectUnknown("st")},
+			&support.Supported{I32: 1, St: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"added":      structpb.NewStringValue("foo"),
+				"also_added": structpb.NewNullValue(),
+			}}},
+			false,
+		},
+		{"collect wrong type", []Option{WithCollectUnknown("i64")}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScanOne[*support.Supported](newTestRows(names, rows), tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ScanOne() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("ScanOne() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScan_options(t *testing.T) {
+	type args struct {
+		names []string
+		rows  [][]interface{}
+		opts  []Option
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantResults []*support.Unsupported
+		wantErr     bool
+	}{
+		{
+			"JSON message",
+			args{
+				[]string{"sup"},
+				[][]interface{}{
+					{[]byte(`{"en": "title", "rI32": [1, 2]}`)},
+					{nil},
+				},
+				[]Option{WithMessageEncoding(JSON)},
+			},
+			[]*support.Unsupported{
+				{Sup: &support.Supported{En: support.SimpleColumns_title, RI32: []int32{1, 2}}},
+				{},
+			},
+			false,
+		},
+		{
+			"JSON message, unknown field",
+			args{
+				[]string{"sup"},
+				[][]interface{}{
+					{[]byte(`{"foo": "bar"}`)},
+				},
+				[]Option{WithEncoding("sup", JSON)},
+			},
+			nil,
+			true,
+		},
+		{
+			"JSON message, discard unknown",
+			args{
+				[]string{"sup"},
+				[][]interface{}{
+					{[]byte(`{"foo": "bar", "i32": 1}`)},
+				},
+				[]Option{
+					WithEncoding("sup", JSON),
+					WithProtoJSON(protojson.MarshalOptions{}, protojson.UnmarshalOptions{DiscardUnknown: true}),
+				},
+			},
+			[]*support.Unsupported{
+				{Sup: &support.Supported{I32: 1}},
+			},
+			false,
+		},
+		{
+			"Binary message",
+			args{
+				[]string{"sup"},
+				[][]interface{}{
+					{[]byte{0x10, 0x01}},
+					{nil},
+				},
+				[]Option{WithEncoding("sup", Binary)},
+			},
+			[]*support.Unsupported{
+				{Sup: &support.Supported{I32: 1}},
+				{},
+			},
+			false,
+		},
+		{
+			"Binary message error",
+			args{
+				[]string{"sup"},
+				[][]interface{}{
+					{[]byte{0xff}},
+				},
+				[]Option{WithEncoding("sup", Binary)},
+			},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResults, err := Scan[*support.Unsupported](newTestRows(tt.args.names, tt.args.rows), tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if len(gotResults) != len(tt.wantResults) {
+				t.Errorf("Scan() =\n%s\nwant\n%s", gotResults, tt.wantResults)
+			}
+
+			for i, want := range tt.wantResults {
+				if !proto.Equal(gotResults[i], want) {
+					t.Errorf("Scan() =\n%s\nwant\n%s", gotResults[i], want)
+				}
+			}
+		})
+	}
+}
+
 type testServerStream[M proto.Message] struct {
 	ctx     context.Context
 	results []M